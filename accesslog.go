@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// AccessLogEntry carries information about served request.
+type AccessLogEntry struct {
+	// Time request was received.
+	Time time.Time
+	// Time it took to serve request.
+	Duration time.Duration
+	// Request method.
+	Method string
+	// Request URI as sent by client.
+	URI string
+	// Request protocol.
+	Proto string
+	// Client network address.
+	RemoteAddr string
+	// Referer header value.
+	Referer string
+	// User-Agent header value.
+	UserAgent string
+	// Response status code.
+	Status int
+	// Response body size.
+	Bytes int64
+	// Tracing ID.
+	RequestID string
+	// ID of event that caused request.
+	CausationID string
+	// Root event ID of execution chain.
+	CorrelationID string
+}
+
+// Access log middleware.
+// Calls log for every served request.
+// Should be used after Middleware to include tracing.
+func AccessLog(log func(AccessLogEntry)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			start := time.Now()
+			rw := newResponseWriter(w)
+
+			next.ServeHTTP(rw, req)
+
+			entry := AccessLogEntry{
+				Time:       start,
+				Duration:   time.Since(start),
+				Method:     req.Method,
+				URI:        req.RequestURI,
+				Proto:      req.Proto,
+				RemoteAddr: req.RemoteAddr,
+				Referer:    req.Referer(),
+				UserAgent:  req.UserAgent(),
+				Status:     rw.status,
+				Bytes:      rw.size,
+			}
+
+			if entry.URI == "" {
+				entry.URI = req.URL.RequestURI()
+			}
+
+			entry.RequestID, entry.CausationID, entry.CorrelationID = tracingIDs(req.Context())
+
+			log(entry)
+		})
+	}
+}
+
+// Access log writer in Common Log Format.
+// Tracing IDs are appended as quoted fields.
+func CommonLog(w io.Writer) func(AccessLogEntry) {
+	return func(e AccessLogEntry) {
+		fmt.Fprintf(w, "%s %s\n", commonLogLine(e), tracingLogFields(e))
+	}
+}
+
+// Access log writer in Combined Log Format.
+// Tracing IDs are appended as quoted fields.
+func CombinedLog(w io.Writer) func(AccessLogEntry) {
+	return func(e AccessLogEntry) {
+		fmt.Fprintf(
+			w,
+			"%s %q %q %s\n",
+			commonLogLine(e),
+			orDash(e.Referer),
+			orDash(e.UserAgent),
+			tracingLogFields(e),
+		)
+	}
+}
+
+// Access log writer to structured logger.
+func SlogAccessLog(logger *slog.Logger) func(AccessLogEntry) {
+	return func(e AccessLogEntry) {
+		attrs := []any{
+			slog.String("method", e.Method),
+			slog.String("uri", e.URI),
+			slog.String("proto", e.Proto),
+			slog.String("remote_addr", e.RemoteAddr),
+			slog.Int("status", e.Status),
+			slog.Int64("bytes", e.Bytes),
+			slog.Duration("duration", e.Duration),
+		}
+
+		attrs = append(attrs, tracingAttrs(e.RequestID, e.CausationID, e.CorrelationID)...)
+
+		logger.Info("access", attrs...)
+	}
+}
+
+func commonLogLine(e AccessLogEntry) string {
+	return fmt.Sprintf(
+		"%s - - [%s] \"%s %s %s\" %d %d",
+		remoteHost(e.RemoteAddr),
+		e.Time.Format("02/Jan/2006:15:04:05 -0700"),
+		e.Method,
+		e.URI,
+		e.Proto,
+		e.Status,
+		e.Bytes,
+	)
+}
+
+func tracingLogFields(e AccessLogEntry) string {
+	return fmt.Sprintf(
+		"%q %q %q",
+		orDash(e.RequestID),
+		orDash(e.CausationID),
+		orDash(e.CorrelationID),
+	)
+}
+
+func remoteHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return orDash(host)
+	}
+
+	return orDash(addr)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+func tracingAttrs(requestID, causationID, correlationID string) []any {
+	attrs := []any{}
+
+	if requestID != "" {
+		attrs = append(attrs, slog.String(LabelRequestID, requestID))
+	}
+
+	if causationID != "" {
+		attrs = append(attrs, slog.String(LabelCausationID, causationID))
+	}
+
+	if correlationID != "" {
+		attrs = append(attrs, slog.String(LabelCorrelationID, correlationID))
+	}
+
+	return attrs
+}
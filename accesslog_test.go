@@ -0,0 +1,131 @@
+package tracing_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("AccessLog", func() {
+	middleware := tracing.Middleware(tracing.DefaultMetadataOptions, func() string { return "0" })
+	errorHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("error"))
+	})
+
+	It("should capture status, size and tracing", func() {
+		var entry tracing.AccessLogEntry
+
+		handler := middleware(tracing.AccessLog(func(e tracing.AccessLogEntry) { entry = e })(errorHandler))
+		req := httptest.NewRequest(http.MethodGet, "/error?q=1", nil)
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		Expect(entry.Method).To(Equal(http.MethodGet))
+		Expect(entry.URI).To(Equal("/error?q=1"))
+		Expect(entry.Status).To(Equal(http.StatusTeapot))
+		Expect(entry.Bytes).To(BeEquivalentTo(5))
+		Expect(entry.RequestID).To(Equal("0"))
+		Expect(entry.CausationID).To(Equal("0"))
+		Expect(entry.CorrelationID).To(Equal("0"))
+	})
+
+	It("should capture final status after informational status", func() {
+		var entry tracing.AccessLogEntry
+
+		handler := tracing.AccessLog(func(e tracing.AccessLogEntry) { entry = e })(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusEarlyHints)
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+		)
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(entry.Status).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should preserve http.Flusher and io.ReaderFrom", func() {
+		var entry tracing.AccessLogEntry
+
+		handler := tracing.AccessLog(func(e tracing.AccessLogEntry) { entry = e })(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				_, ok := w.(http.Hijacker)
+
+				Expect(ok).To(BeTrue())
+
+				_, err := w.(io.ReaderFrom).ReadFrom(strings.NewReader("body"))
+
+				Expect(err).ShouldNot(HaveOccurred())
+
+				w.(http.Flusher).Flush()
+			}),
+		)
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp.Body.Close()
+
+		Expect(entry.Status).To(Equal(http.StatusOK))
+		Expect(entry.Bytes).To(BeEquivalentTo(4))
+	})
+
+	It("should write Common Log Format", func() {
+		var buf bytes.Buffer
+
+		handler := middleware(tracing.AccessLog(tracing.CommonLog(&buf))(errorHandler))
+		req := httptest.NewRequest(http.MethodGet, "/error", nil)
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		Expect(buf.String()).To(MatchRegexp(
+			`^192\.0\.2\.1 - - \[.+\] "GET /error HTTP/1\.1" 418 5 "0" "0" "0"\n$`,
+		))
+	})
+
+	It("should write Combined Log Format", func() {
+		var buf bytes.Buffer
+
+		handler := middleware(tracing.AccessLog(tracing.CombinedLog(&buf))(errorHandler))
+		req := httptest.NewRequest(http.MethodGet, "/error", nil)
+		req.Header.Set("User-Agent", "test")
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		Expect(buf.String()).To(MatchRegexp(
+			`^192\.0\.2\.1 - - \[.+\] "GET /error HTTP/1\.1" 418 5 "-" "test" "0" "0" "0"\n$`,
+		))
+	})
+
+	It("should write structured log", func() {
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		handler := middleware(tracing.AccessLog(tracing.SlogAccessLog(logger))(errorHandler))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/error", nil))
+
+		record := map[string]any{}
+
+		Expect(json.Unmarshal(buf.Bytes(), &record)).ShouldNot(HaveOccurred())
+		Expect(record).To(HaveKeyWithValue("status", BeEquivalentTo(http.StatusTeapot)))
+		Expect(record).To(HaveKeyWithValue(tracing.LabelRequestID, "0"))
+		Expect(record).To(HaveKeyWithValue(tracing.LabelCorrelationID, "0"))
+	})
+})
@@ -0,0 +1,147 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	// B3 single header name.
+	HeaderB3 string = "B3"
+	// B3 TraceId header name.
+	HeaderB3TraceID string = "X-B3-Traceid"
+	// B3 SpanId header name.
+	HeaderB3SpanID string = "X-B3-Spanid"
+	// B3 ParentSpanId header name.
+	HeaderB3ParentSpanID string = "X-B3-Parentspanid"
+	// B3 Sampled header name.
+	HeaderB3Sampled string = "X-B3-Sampled"
+	// B3 Flags header name.
+	HeaderB3Flags string = "X-B3-Flags"
+)
+
+var (
+	// Metadata reader from B3 single or multiple Headers.
+	B3ReadHeader = readB3
+	// Metadata writer to B3 multiple Headers.
+	B3WriteHeader = writeB3
+	// Metadata writer to B3 single Header.
+	B3SingleWriteHeader = writeB3Single
+	// Metadata options using B3 multiple Headers.
+	B3Options = MetadataOptions(B3ReadHeader, B3WriteHeader)
+	// Metadata options using B3 single Header.
+	B3SingleOptions = MetadataOptions(B3ReadHeader, B3SingleWriteHeader)
+)
+
+// Maps TraceId to CorrelationID, SpanId to ID and ParentSpanId to CausationID.
+// CausationID is SpanId if ParentSpanId is absent.
+// Sampling state and debug flag are kept as Sampling.
+func readB3(header http.Header, id string) (Metadata, bool) {
+	traceID := header.Get(HeaderB3TraceID)
+	spanID := header.Get(HeaderB3SpanID)
+	parentSpanID := header.Get(HeaderB3ParentSpanID)
+	sampling := header.Get(HeaderB3Sampled)
+
+	if header.Get(HeaderB3Flags) == "1" {
+		sampling = "d"
+	}
+
+	if single := header.Get(HeaderB3); single != "" {
+		parts := strings.Split(single, "-")
+		if len(parts) < 2 {
+			return NewMetadata(id), false
+		}
+
+		traceID, spanID, parentSpanID, sampling = parts[0], parts[1], "", ""
+		if len(parts) > 2 {
+			sampling = parts[2]
+		}
+
+		if len(parts) == 4 {
+			parentSpanID = parts[3]
+		}
+	}
+
+	if !isB3TraceID(traceID) || !isHexID(spanID, 16) {
+		return NewMetadata(id), false
+	}
+
+	if parentSpanID == "" {
+		parentSpanID = spanID
+	}
+
+	return Metadata{
+		ID:            spanID,
+		CausationID:   parentSpanID,
+		CorrelationID: traceID,
+		Sampling:      b3Sampling(sampling),
+	}, true
+}
+
+// Writes TraceId, SpanId and Sampled or Flags for debug traces.
+// ParentSpanId is omitted for root events, Sampled is omitted if Sampling is unknown.
+func writeB3(header http.Header, m Metadata) {
+	header.Set(HeaderB3TraceID, hexID(m.CorrelationID, 32))
+	header.Set(HeaderB3SpanID, hexID(m.ID, 16))
+
+	if m.CausationID != m.ID {
+		header.Set(HeaderB3ParentSpanID, hexID(m.CausationID, 16))
+	}
+
+	switch sampling := b3SamplingState(m.Sampling); sampling {
+	case "":
+	case "d":
+		header.Set(HeaderB3Flags, "1")
+	default:
+		header.Set(HeaderB3Sampled, sampling)
+	}
+}
+
+// Writes "TraceId-SpanId-SamplingState-ParentSpanId".
+// ParentSpanId is omitted for root events.
+// Only "TraceId-SpanId" is written if Sampling is unknown,
+// as ParentSpanId can not follow absent SamplingState.
+func writeB3Single(header http.Header, m Metadata) {
+	v := hexID(m.CorrelationID, 32) + "-" + hexID(m.ID, 16)
+
+	if sampling := b3SamplingState(m.Sampling); sampling != "" {
+		v += "-" + sampling
+
+		if m.CausationID != m.ID {
+			v += "-" + hexID(m.CausationID, 16)
+		}
+	}
+
+	header.Set(HeaderB3, v)
+}
+
+func b3Sampling(state string) Sampling {
+	switch state {
+	case "d":
+		return SamplingUserKeep
+	case "1", "true":
+		return SamplingKeep
+	case "0", "false":
+		return SamplingDrop
+	default:
+		return SamplingUnknown
+	}
+}
+
+// Empty for unknown Sampling.
+func b3SamplingState(s Sampling) string {
+	switch s {
+	case SamplingUnknown:
+		return ""
+	case SamplingUserKeep:
+		return "d"
+	case SamplingKeep:
+		return "1"
+	default:
+		return "0"
+	}
+}
+
+func isB3TraceID(id string) bool {
+	return isHexID(id, 32) || isHexID(id, 16)
+}
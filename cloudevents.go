@@ -0,0 +1,150 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// CloudEvents binary mode header name prefix.
+	HeaderCloudEventsPrefix string = "Ce-"
+	// CloudEvents id attribute name.
+	CloudEventsID string = "id"
+	// CloudEvents distributed tracing extension attribute name.
+	CloudEventsTraceParent string = "traceparent"
+	// Default CloudEvents RequestID extension attribute name.
+	DefaultCloudEventsRequestID string = "requestid"
+	// Default CloudEvents CorrelationID extension attribute name.
+	DefaultCloudEventsCorrelationID string = "correlationid"
+	// Default CloudEvents CausationID extension attribute name.
+	DefaultCloudEventsCausationID string = "causationid"
+)
+
+var (
+	// Metadata reader from CloudEvents binary mode Header using default extension names.
+	DefaultCloudEventsReadHeader = CloudEventsReadHeader(
+		DefaultCloudEventsRequestID,
+		DefaultCloudEventsCausationID,
+		DefaultCloudEventsCorrelationID,
+	)
+	// Metadata writer to CloudEvents binary mode Header using default extension names.
+	DefaultCloudEventsWriteHeader = CloudEventsWriteHeader(
+		DefaultCloudEventsRequestID,
+		DefaultCloudEventsCausationID,
+		DefaultCloudEventsCorrelationID,
+	)
+	// Metadata options for CloudEvents binary mode with default extension names.
+	DefaultCloudEventsOptions = MetadataOptions(DefaultCloudEventsReadHeader, DefaultCloudEventsWriteHeader)
+)
+
+// Metadata reader from CloudEvents binary mode (ce-*) Header using provided extension names.
+func CloudEventsReadHeader(
+	requestID, causationID, correlationID string,
+) func(header http.Header, id string) (Metadata, bool) {
+	return func(header http.Header, id string) (Metadata, bool) {
+		attributes := map[string]any{}
+
+		for _, name := range []string{CloudEventsID, CloudEventsTraceParent, requestID, causationID, correlationID} {
+			if v := header.Get(HeaderCloudEventsPrefix + name); v != "" {
+				attributes[name] = v
+			}
+		}
+
+		return readCloudEventsAttributes(attributes, requestID, causationID, correlationID, id)
+	}
+}
+
+// Metadata writer to CloudEvents binary mode (ce-*) Header using provided extension names.
+// Event id is not written.
+func CloudEventsWriteHeader(requestID, causationID, correlationID string) func(http.Header, Metadata) {
+	return func(header http.Header, m Metadata) {
+		attributes := map[string]any{}
+
+		CloudEventsWriteAttributes(attributes, requestID, causationID, correlationID, m)
+
+		for name, v := range attributes {
+			header.Set(HeaderCloudEventsPrefix+name, v.(string))
+		}
+	}
+}
+
+// Writes Metadata to CloudEvents structured mode attributes
+// (or event extensions) using provided extension names.
+// Event id is not written, as it must stay unique for every event.
+func CloudEventsWriteAttributes(
+	attributes map[string]any,
+	requestID, causationID, correlationID string,
+	m Metadata,
+) {
+	attributes[CloudEventsTraceParent] = traceParent(m)
+	attributes[requestID] = m.ID
+	attributes[causationID] = m.CausationID
+	attributes[correlationID] = m.CorrelationID
+}
+
+// Reads Metadata from CloudEvents structured mode attributes
+// (or event extensions) using provided extension names.
+// Falls back to traceparent extension if CausationID or CorrelationID extensions are absent
+// and to event id if RequestID extension is absent.
+func CloudEventsReadAttributes(
+	attributes map[string]any,
+	requestID, causationID, correlationID string,
+) (Metadata, bool) {
+	m, ok := readCloudEventsAttributes(attributes, requestID, causationID, correlationID, "")
+	if !ok {
+		return Metadata{}, false
+	}
+
+	return m, true
+}
+
+// Adds Metadata read from CloudEvents structured mode attributes to context.
+// Returns ctx as is if attributes have no tracing.
+func WithCloudEventsTracing(
+	ctx context.Context,
+	attributes map[string]any,
+	requestID, causationID, correlationID string,
+) context.Context {
+	if m, ok := CloudEventsReadAttributes(attributes, requestID, causationID, correlationID); ok {
+		return WithTracing(ctx, m)
+	}
+
+	return ctx
+}
+
+func readCloudEventsAttributes(
+	attributes map[string]any,
+	requestID, causationID, correlationID, id string,
+) (Metadata, bool) {
+	attribute := func(name string) string {
+		v, _ := attributes[name].(string)
+		return v
+	}
+
+	m := Metadata{
+		ID:            attribute(requestID),
+		CausationID:   attribute(causationID),
+		CorrelationID: attribute(correlationID),
+	}
+
+	traceID, parentID, sampling, ok := parseTraceParent(attribute(CloudEventsTraceParent))
+	if m.CausationID == "" || m.CorrelationID == "" {
+		if !ok {
+			return NewMetadata(id), false
+		}
+
+		m.CausationID, m.CorrelationID = parentID, traceID
+	}
+
+	m.Sampling = sampling
+
+	if m.ID == "" {
+		m.ID = attribute(CloudEventsID)
+	}
+
+	if m.ID == "" {
+		m.ID = m.CausationID
+	}
+
+	return m, true
+}
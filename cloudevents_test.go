@@ -0,0 +1,141 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+	"github.com/andriiyaremenko/tracing/tracingtest"
+)
+
+var _ = Describe("CloudEvents", func() {
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+
+	metadata := tracing.Metadata{ID: "2", CausationID: "1", CorrelationID: "0", Sampling: tracing.SamplingDrop}
+
+	Context("binary mode", func() {
+		It("should write and read ce-* headers", func() {
+			header := http.Header{}
+			tracing.DefaultCloudEventsWriteHeader(header, metadata)
+
+			Expect(header).NotTo(HaveKey("Ce-Id"))
+			Expect(header.Get("ce-requestid")).To(Equal("2"))
+			Expect(header.Get("ce-causationid")).To(Equal("1"))
+			Expect(header.Get("ce-correlationid")).To(Equal("0"))
+			Expect(header.Get("ce-traceparent")).To(MatchRegexp(`^00-[0-9a-f]{32}-[0-9a-f]{16}-00$`))
+
+			m, ok := tracing.DefaultCloudEventsReadHeader(header, "3")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(metadata))
+		})
+
+		It("should use custom extension names in middleware", func() {
+			middleware := tracing.Middleware(
+				tracing.MetadataOptions(
+					tracing.CloudEventsReadHeader("myrequest", "mycausation", "mycorrelation"),
+					tracing.CloudEventsWriteHeader("myrequest", "mycausation", "mycorrelation"),
+				),
+				func() string { return "3" },
+			)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("ce-id", "2")
+			req.Header.Set("ce-mycausation", "1")
+			req.Header.Set("ce-mycorrelation", "0")
+
+			result := tracingtest.Serve[tracing.Metadata](middleware, nil, req)
+
+			Expect(result.Tracing).To(Equal(tracing.Metadata{ID: "3", CausationID: "2", CorrelationID: "0", Hops: 1}))
+			Expect(result.Response.Header.Get("ce-mycausation")).To(Equal("2"))
+			Expect(result.Response.Header.Get("ce-myrequest")).To(Equal("3"))
+		})
+
+		It("should keep event id of every event sent from one context", func() {
+			var ids []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ids = append(ids, r.Header.Get("ce-id"))
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: tracing.Transport(nil, tracing.DefaultCloudEventsOptions)}
+			ctx := tracing.WithTracing(context.Background(), metadata)
+
+			for _, id := range []string{"event-a", "event-b"} {
+				req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+				req.Header.Set("ce-id", id)
+
+				resp, err := client.Do(req)
+
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp.Body.Close()
+			}
+
+			Expect(ids).To(Equal([]string{"event-a", "event-b"}))
+		})
+	})
+
+	Context("structured mode", func() {
+		It("should write and read attributes", func() {
+			attributes := map[string]any{"specversion": "1.0"}
+			tracing.CloudEventsWriteAttributes(
+				attributes,
+				tracing.DefaultCloudEventsRequestID,
+				tracing.DefaultCloudEventsCausationID,
+				tracing.DefaultCloudEventsCorrelationID,
+				metadata,
+			)
+
+			Expect(attributes).To(HaveKeyWithValue("specversion", "1.0"))
+
+			ctx := tracing.WithCloudEventsTracing(
+				context.Background(),
+				attributes,
+				tracing.DefaultCloudEventsRequestID,
+				tracing.DefaultCloudEventsCausationID,
+				tracing.DefaultCloudEventsCorrelationID,
+			)
+			m, ok := tracing.GetTracing[tracing.Metadata](ctx)
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(metadata))
+		})
+
+		It("should fall back to traceparent", func() {
+			m, ok := tracing.CloudEventsReadAttributes(
+				map[string]any{"id": "2", "traceparent": "00-" + traceID + "-" + parentID + "-01"},
+				tracing.DefaultCloudEventsRequestID,
+				tracing.DefaultCloudEventsCausationID,
+				tracing.DefaultCloudEventsCorrelationID,
+			)
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{
+				ID:            "2",
+				CausationID:   parentID,
+				CorrelationID: traceID,
+				Sampling:      tracing.SamplingKeep,
+			}))
+		})
+
+		It("should report missing tracing", func() {
+			_, ok := tracing.CloudEventsReadAttributes(
+				map[string]any{"id": "2"},
+				tracing.DefaultCloudEventsRequestID,
+				tracing.DefaultCloudEventsCausationID,
+				tracing.DefaultCloudEventsCorrelationID,
+			)
+
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package tracing
+
+import "net/http"
+
+// Options that read Tracing with the first of opts that finds it in Header
+// and write Tracing with all opts.
+// New and next Tracing are calculated by first.
+// Can be used to support several header formats during migration.
+func CompositeOptions[T Metadata | RequestID](first Options[T], rest ...Options[T]) Options[T] {
+	return func() (ReadHeader[T], WriteHeader[T], Next[T]) {
+		read, write, next := first()
+		reads := []ReadHeader[T]{read}
+		writes := []WriteHeader[T]{write}
+
+		for _, opts := range rest {
+			read, write, _ := opts()
+
+			reads = append(reads, read)
+			writes = append(writes, write)
+		}
+
+		readAny := func(header http.Header, id string) (T, bool) {
+			t, ok := reads[0](header, id)
+			if ok {
+				return t, true
+			}
+
+			for _, read := range reads[1:] {
+				if v, ok := read(header, id); ok {
+					return v, true
+				}
+			}
+
+			return t, false
+		}
+		writeAll := func(header http.Header, t T) {
+			for _, write := range writes {
+				write(header, t)
+			}
+		}
+
+		return readAny, writeAll, next
+	}
+}
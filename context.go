@@ -4,7 +4,14 @@ import "context"
 
 type key int
 
-var tracingKey key
+const (
+	tracingKey key = iota
+	baggageKey
+	retryAttemptKey
+)
+
+// Baggage carries key-value pairs propagated together with tracing.
+type Baggage map[string]string
 
 // Adds tracing to context.
 func WithTracing[T Metadata | RequestID](ctx context.Context, t T) context.Context {
@@ -16,3 +23,39 @@ func GetTracing[T Metadata | RequestID](ctx context.Context) (T, bool) {
 	v, ok := ctx.Value(tracingKey).(T)
 	return v, ok
 }
+
+// Adds baggage to context.
+func WithBaggage(ctx context.Context, b Baggage) context.Context {
+	return context.WithValue(ctx, baggageKey, b)
+}
+
+// Reads baggage from context.
+func GetBaggage(ctx context.Context) (Baggage, bool) {
+	b, ok := ctx.Value(baggageKey).(Baggage)
+	return b, ok
+}
+
+// Adds retry attempt number to context.
+func WithRetryAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryAttemptKey, attempt)
+}
+
+// Reads retry attempt number from context.
+func GetRetryAttempt(ctx context.Context) (int, bool) {
+	attempt, ok := ctx.Value(retryAttemptKey).(int)
+	return attempt, ok
+}
+
+// Reads tracing IDs from context.
+// Returns empty strings for IDs that are not present.
+func tracingIDs(ctx context.Context) (requestID, causationID, correlationID string) {
+	if m, ok := GetTracing[Metadata](ctx); ok {
+		return m.ID, m.CausationID, m.CorrelationID
+	}
+
+	if r, ok := GetTracing[RequestID](ctx); ok {
+		return string(r), "", ""
+	}
+
+	return "", "", ""
+}
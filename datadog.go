@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const (
+	// Datadog trace ID header name.
+	HeaderDatadogTraceID string = "X-Datadog-Trace-Id"
+	// Datadog parent ID header name.
+	HeaderDatadogParentID string = "X-Datadog-Parent-Id"
+	// Datadog sampling priority header name.
+	HeaderDatadogSamplingPriority string = "X-Datadog-Sampling-Priority"
+	// Datadog origin header name.
+	HeaderDatadogOrigin string = "X-Datadog-Origin"
+	// Datadog origin baggage key.
+	DatadogBaggageOrigin string = "_dd.origin"
+)
+
+var (
+	// Metadata reader from Datadog Headers.
+	DatadogReadHeader = readDatadog
+	// Metadata writer to Datadog Headers.
+	DatadogWriteHeader = writeDatadog
+	// Metadata options using Datadog Headers.
+	DatadogOptions = MetadataOptions(DatadogReadHeader, DatadogWriteHeader)
+)
+
+// Reads Datadog origin from Header to DatadogBaggageOrigin entry.
+// Use with WithBaggageHeader to read origin to request context.
+func DatadogBaggageReadHeader(header http.Header) Baggage {
+	if origin := header.Get(HeaderDatadogOrigin); origin != "" {
+		return Baggage{DatadogBaggageOrigin: origin}
+	}
+
+	return Baggage{}
+}
+
+// Writes DatadogBaggageOrigin entry of b to origin Header.
+// Use with WithTransportBaggage to write origin from request context.
+func DatadogBaggageWriteHeader(header http.Header, b Baggage) {
+	if origin := b[DatadogBaggageOrigin]; origin != "" {
+		header.Set(HeaderDatadogOrigin, origin)
+	}
+}
+
+// Maps trace ID to CorrelationID, parent ID to ID and CausationID
+// and sampling priority to Sampling.
+// Decimal IDs are converted to 64-bit hex strings.
+func readDatadog(header http.Header, id string) (Metadata, bool) {
+	traceID, err := strconv.ParseUint(header.Get(HeaderDatadogTraceID), 10, 64)
+	if err != nil || traceID == 0 {
+		return NewMetadata(id), false
+	}
+
+	parentID, err := strconv.ParseUint(header.Get(HeaderDatadogParentID), 10, 64)
+	if err != nil || parentID == 0 {
+		return NewMetadata(id), false
+	}
+
+	sampling := SamplingUnknown
+	if priority, err := strconv.Atoi(header.Get(HeaderDatadogSamplingPriority)); err == nil {
+		if s := Sampling(priority + 2); s != SamplingUnknown && validSampling(s) {
+			sampling = s
+		}
+	}
+
+	return Metadata{
+		ID:            hexUint64(parentID),
+		CausationID:   hexUint64(parentID),
+		CorrelationID: hexUint64(traceID),
+		Sampling:      sampling,
+	}, true
+}
+
+// Writes IDs as decimal 64-bit IDs and Sampling as sampling priority.
+// Sampling priority is omitted if Sampling is unknown, so Datadog agent decides.
+func writeDatadog(header http.Header, m Metadata) {
+	header.Set(HeaderDatadogTraceID, strconv.FormatUint(datadogTraceID(m.CorrelationID), 10))
+	header.Set(HeaderDatadogParentID, strconv.FormatUint(uint64ID(m.ID), 10))
+
+	if m.Sampling != SamplingUnknown {
+		header.Set(HeaderDatadogSamplingPriority, strconv.Itoa(int(m.Sampling)-2))
+	}
+}
+
+// Datadog trace ID is lower 64 bits of 128-bit trace ID,
+// so it matches trace ID written in other formats.
+func datadogTraceID(id string) uint64 {
+	if v, _ := strconv.ParseUint(hexID(id, 32)[16:], 16, 64); v != 0 {
+		return v
+	}
+
+	return uint64ID(id)
+}
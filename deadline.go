@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Default deadline header name.
+// Value is remaining time in gRPC timeout format, e.g. "250m" for 250 milliseconds.
+const HeaderRequestDeadline string = "X-Request-Deadline"
+
+var timeoutUnits = []struct {
+	suffix byte
+	unit   time.Duration
+}{
+	{'n', time.Nanosecond},
+	{'u', time.Microsecond},
+	{'m', time.Millisecond},
+	{'S', time.Second},
+	{'M', time.Minute},
+	{'H', time.Hour},
+}
+
+// Formats timeout in gRPC timeout format with at most 8 digits.
+func formatTimeout(d time.Duration) string {
+	if d <= 0 {
+		return "0n"
+	}
+
+	var (
+		v      time.Duration
+		suffix byte
+	)
+
+	// Max time.Duration is about 2.6 million hours, so hours always fit.
+	for _, u := range timeoutUnits {
+		// Round up, so that timeout is never shortened to zero.
+		if v, suffix = d/u.unit, u.suffix; d%u.unit != 0 {
+			v++
+		}
+
+		if v < 1e8 {
+			break
+		}
+	}
+
+	return strconv.FormatInt(int64(v), 10) + string(suffix)
+}
+
+// Parses timeout in gRPC timeout format.
+// Timeouts exceeding max time.Duration are clamped to it.
+func parseTimeout(s string) (time.Duration, bool) {
+	if len(s) < 2 || len(s) > 9 {
+		return 0, false
+	}
+
+	v, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+	if err != nil || v < 0 {
+		return 0, false
+	}
+
+	for _, u := range timeoutUnits {
+		if s[len(s)-1] != u.suffix {
+			continue
+		}
+
+		if v > math.MaxInt64/int64(u.unit) {
+			return math.MaxInt64, true
+		}
+
+		return time.Duration(v) * u.unit, true
+	}
+
+	return 0, false
+}
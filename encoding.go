@@ -0,0 +1,292 @@
+package tracing
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const encodingVersion byte = 1
+
+// Returned when tracing can not be decoded.
+var ErrInvalidEncoding = errors.New("tracing: invalid encoding")
+
+type metadataJSON struct {
+	ID            string   `json:"id"`
+	CorrelationID string   `json:"correlationId"`
+	CausationID   string   `json:"causationId"`
+	Hops          int      `json:"hops,omitempty"`
+	Sampling      Sampling `json:"sampling,omitempty"`
+}
+
+// Encodes Metadata as JSON object.
+func (m Metadata) MarshalJSON() ([]byte, error) {
+	return json.Marshal(metadataJSON(m))
+}
+
+// Decodes Metadata from JSON object.
+func (m *Metadata) UnmarshalJSON(b []byte) error {
+	var v metadataJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Hops < 0 {
+		return fmt.Errorf("%w: %d is not hop count", ErrInvalidEncoding, v.Hops)
+	}
+
+	if !validSampling(v.Sampling) {
+		return fmt.Errorf("%w: %d is not sampling decision", ErrInvalidEncoding, v.Sampling)
+	}
+
+	*m = Metadata(v)
+
+	return nil
+}
+
+// Encodes Metadata as "ID;CausationID;CorrelationID[;Hops[;Sampling]]" with escaped IDs.
+// Hops are omitted if 0 and Sampling is unknown, Sampling is omitted if unknown.
+func (m Metadata) MarshalText() ([]byte, error) {
+	text := url.PathEscape(m.ID) + ";" +
+		url.PathEscape(m.CausationID) + ";" +
+		url.PathEscape(m.CorrelationID)
+
+	if m.Hops > 0 || m.Sampling != SamplingUnknown {
+		text += ";" + strconv.Itoa(m.Hops)
+	}
+
+	if m.Sampling != SamplingUnknown {
+		text += ";" + strconv.Itoa(int(m.Sampling))
+	}
+
+	return []byte(text), nil
+}
+
+// Decodes Metadata from "ID;CausationID;CorrelationID[;Hops[;Sampling]]".
+func (m *Metadata) UnmarshalText(b []byte) error {
+	parts := strings.Split(string(b), ";")
+	if len(parts) < 3 || len(parts) > 5 {
+		return fmt.Errorf("%w: %q is not Metadata", ErrInvalidEncoding, b)
+	}
+
+	ids := make([]string, 3)
+	for i, p := range parts[:3] {
+		id, err := url.PathUnescape(p)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidEncoding, err)
+		}
+
+		ids[i] = id
+	}
+
+	hops := 0
+	if len(parts) > 3 {
+		var err error
+		if hops, err = strconv.Atoi(parts[3]); err != nil || hops < 0 {
+			return fmt.Errorf("%w: %q is not hop count", ErrInvalidEncoding, parts[3])
+		}
+	}
+
+	sampling := 0
+	if len(parts) > 4 {
+		var err error
+		if sampling, err = strconv.Atoi(parts[4]); err != nil || !validSampling(Sampling(sampling)) {
+			return fmt.Errorf("%w: %q is not sampling decision", ErrInvalidEncoding, parts[4])
+		}
+	}
+
+	*m = Metadata{
+		ID:            ids[0],
+		CausationID:   ids[1],
+		CorrelationID: ids[2],
+		Hops:          hops,
+		Sampling:      Sampling(sampling),
+	}
+
+	return nil
+}
+
+// Encodes Metadata in compact binary form.
+// Hops are omitted if 0 and Sampling is unknown, Sampling is omitted if unknown.
+func (m Metadata) MarshalBinary() ([]byte, error) {
+	b := appendBinary([]byte{encodingVersion}, m.ID, m.CausationID, m.CorrelationID)
+
+	if m.Hops > 0 || m.Sampling != SamplingUnknown {
+		b = binary.AppendUvarint(b, uint64(m.Hops))
+	}
+
+	if m.Sampling != SamplingUnknown {
+		b = append(b, byte(m.Sampling))
+	}
+
+	return b, nil
+}
+
+// Decodes Metadata from compact binary form.
+func (m *Metadata) UnmarshalBinary(b []byte) error {
+	ids, rest, err := readBinary(b, 3)
+	if err != nil {
+		return err
+	}
+
+	hops := uint64(0)
+	if len(rest) != 0 {
+		var read int
+		if hops, read = binary.Uvarint(rest); read <= 0 || hops > math.MaxInt {
+			return fmt.Errorf("%w: invalid hop count in binary", ErrInvalidEncoding)
+		}
+
+		rest = rest[read:]
+	}
+
+	sampling := SamplingUnknown
+	if len(rest) != 0 {
+		if sampling = Sampling(rest[0]); len(rest) != 1 || !validSampling(sampling) {
+			return fmt.Errorf("%w: invalid sampling decision in binary", ErrInvalidEncoding)
+		}
+	}
+
+	*m = Metadata{
+		ID:            ids[0],
+		CausationID:   ids[1],
+		CorrelationID: ids[2],
+		Hops:          int(hops),
+		Sampling:      sampling,
+	}
+
+	return nil
+}
+
+// Stores Metadata as JSON.
+func (m Metadata) Value() (driver.Value, error) {
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// Reads Metadata stored as JSON.
+func (m *Metadata) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = Metadata{}
+		return nil
+	case string:
+		return m.UnmarshalJSON([]byte(v))
+	case []byte:
+		return m.UnmarshalJSON(v)
+	default:
+		return fmt.Errorf("%w: can not scan %T into Metadata", ErrInvalidEncoding, src)
+	}
+}
+
+// Encodes RequestID as JSON string.
+func (r RequestID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r))
+}
+
+// Decodes RequestID from JSON string.
+func (r *RequestID) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	*r = RequestID(s)
+
+	return nil
+}
+
+// Encodes RequestID as text.
+func (r RequestID) MarshalText() ([]byte, error) {
+	return []byte(r), nil
+}
+
+// Decodes RequestID from text.
+func (r *RequestID) UnmarshalText(b []byte) error {
+	*r = RequestID(b)
+
+	return nil
+}
+
+// Encodes RequestID in compact binary form.
+func (r RequestID) MarshalBinary() ([]byte, error) {
+	return appendBinary([]byte{encodingVersion}, string(r)), nil
+}
+
+// Decodes RequestID from compact binary form.
+func (r *RequestID) UnmarshalBinary(b []byte) error {
+	ids, rest, err := readBinary(b, 1)
+	if err != nil {
+		return err
+	}
+
+	if len(rest) != 0 {
+		return fmt.Errorf("%w: trailing bytes in binary", ErrInvalidEncoding)
+	}
+
+	*r = RequestID(ids[0])
+
+	return nil
+}
+
+// Stores RequestID as string.
+func (r RequestID) Value() (driver.Value, error) {
+	return string(r), nil
+}
+
+// Reads RequestID stored as string.
+func (r *RequestID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*r = ""
+		return nil
+	case string:
+		*r = RequestID(v)
+		return nil
+	case []byte:
+		*r = RequestID(v)
+		return nil
+	default:
+		return fmt.Errorf("%w: can not scan %T into RequestID", ErrInvalidEncoding, src)
+	}
+}
+
+func appendBinary(b []byte, ids ...string) []byte {
+	for _, id := range ids {
+		b = binary.AppendUvarint(b, uint64(len(id)))
+		b = append(b, id...)
+	}
+
+	return b
+}
+
+// Reads n IDs and returns them with remaining bytes.
+func readBinary(b []byte, n int) ([]string, []byte, error) {
+	if len(b) == 0 || b[0] != encodingVersion {
+		return nil, nil, fmt.Errorf("%w: unsupported binary version", ErrInvalidEncoding)
+	}
+
+	b = b[1:]
+	ids := make([]string, n)
+
+	for i := range ids {
+		size, read := binary.Uvarint(b)
+		if read <= 0 || uint64(len(b)-read) < size {
+			return nil, nil, fmt.Errorf("%w: truncated binary", ErrInvalidEncoding)
+		}
+
+		ids[i] = string(b[read : read+int(size)])
+		b = b[read+int(size):]
+	}
+
+	return ids, b, nil
+}
@@ -0,0 +1,174 @@
+package tracing_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("Encoding", func() {
+	metadata := tracing.Metadata{ID: "2", CausationID: "1;x", CorrelationID: "0"}
+
+	Context("Metadata", func() {
+		It("should round trip JSON", func() {
+			b, err := json.Marshal(metadata)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(b).To(MatchJSON(`{"id":"2","causationId":"1;x","correlationId":"0"}`))
+
+			var m tracing.Metadata
+
+			Expect(json.Unmarshal(b, &m)).ShouldNot(HaveOccurred())
+			Expect(m).To(Equal(metadata))
+		})
+
+		It("should round trip text", func() {
+			b, err := metadata.MarshalText()
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(b)).To(Equal("2;1%3Bx;0"))
+
+			var m tracing.Metadata
+
+			Expect(m.UnmarshalText(b)).ShouldNot(HaveOccurred())
+			Expect(m).To(Equal(metadata))
+			Expect(m.UnmarshalText([]byte("2;1"))).To(MatchError(tracing.ErrInvalidEncoding))
+		})
+
+		It("should round trip binary", func() {
+			b, err := metadata.MarshalBinary()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var m tracing.Metadata
+
+			Expect(m.UnmarshalBinary(b)).ShouldNot(HaveOccurred())
+			Expect(m).To(Equal(metadata))
+			Expect(m.UnmarshalBinary(b[:len(b)-1])).To(MatchError(tracing.ErrInvalidEncoding))
+		})
+
+		It("should round trip hops", func() {
+			m := tracing.NextMetadata(metadata, "3")
+
+			b, err := json.Marshal(m)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(b).To(MatchJSON(`{"id":"3","causationId":"2","correlationId":"0","hops":1}`))
+
+			text, _ := m.MarshalText()
+
+			Expect(string(text)).To(Equal("3;2;0;1"))
+
+			var decoded tracing.Metadata
+
+			Expect(decoded.UnmarshalText(text)).ShouldNot(HaveOccurred())
+			Expect(decoded).To(Equal(m))
+
+			bin, _ := m.MarshalBinary()
+
+			Expect(decoded.UnmarshalBinary(bin)).ShouldNot(HaveOccurred())
+			Expect(decoded).To(Equal(m))
+		})
+
+		It("should round trip sampling", func() {
+			m := metadata
+			m.Sampling = tracing.SamplingDrop
+
+			b, err := json.Marshal(m)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(b).To(MatchJSON(`{"id":"2","causationId":"1;x","correlationId":"0","sampling":2}`))
+
+			text, _ := m.MarshalText()
+
+			Expect(string(text)).To(Equal("2;1%3Bx;0;0;2"))
+
+			var decoded tracing.Metadata
+
+			Expect(decoded.UnmarshalText(text)).ShouldNot(HaveOccurred())
+			Expect(decoded).To(Equal(m))
+			Expect(decoded.UnmarshalText([]byte("2;1;0;0;9"))).To(MatchError(tracing.ErrInvalidEncoding))
+
+			bin, _ := m.MarshalBinary()
+
+			Expect(decoded.UnmarshalBinary(bin)).ShouldNot(HaveOccurred())
+			Expect(decoded).To(Equal(m))
+			Expect(decoded.UnmarshalBinary(append(bin, 0))).To(MatchError(tracing.ErrInvalidEncoding))
+		})
+
+		It("should reject invalid hops and sampling", func() {
+			var m tracing.Metadata
+
+			Expect(json.Unmarshal(
+				[]byte(`{"id":"2","causationId":"1","correlationId":"0","hops":-1}`),
+				&m,
+			)).To(MatchError(tracing.ErrInvalidEncoding))
+			Expect(json.Unmarshal(
+				[]byte(`{"id":"2","causationId":"1","correlationId":"0","sampling":9}`),
+				&m,
+			)).To(MatchError(tracing.ErrInvalidEncoding))
+
+			b, _ := metadata.MarshalBinary()
+			b = binary.AppendUvarint(b, math.MaxUint64)
+
+			Expect(m.UnmarshalBinary(b)).To(MatchError(tracing.ErrInvalidEncoding))
+		})
+
+		It("should round trip SQL value", func() {
+			v, err := metadata.Value()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var m tracing.Metadata
+
+			Expect(m.Scan(v)).ShouldNot(HaveOccurred())
+			Expect(m).To(Equal(metadata))
+			Expect(m.Scan([]byte(v.(string)))).ShouldNot(HaveOccurred())
+			Expect(m).To(Equal(metadata))
+			Expect(m.Scan(1)).To(MatchError(tracing.ErrInvalidEncoding))
+		})
+	})
+
+	Context("RequestID", func() {
+		requestID := tracing.RequestID("1")
+
+		It("should round trip JSON", func() {
+			b, err := json.Marshal(requestID)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(b).To(MatchJSON(`"1"`))
+
+			var r tracing.RequestID
+
+			Expect(json.Unmarshal(b, &r)).ShouldNot(HaveOccurred())
+			Expect(r).To(Equal(requestID))
+		})
+
+		It("should round trip binary", func() {
+			b, err := requestID.MarshalBinary()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var r tracing.RequestID
+
+			Expect(r.UnmarshalBinary(b)).ShouldNot(HaveOccurred())
+			Expect(r).To(Equal(requestID))
+		})
+
+		It("should round trip SQL value", func() {
+			v, err := requestID.Value()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var r tracing.RequestID
+
+			Expect(r.Scan(v)).ShouldNot(HaveOccurred())
+			Expect(r).To(Equal(requestID))
+		})
+	})
+})
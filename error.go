@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+)
+
+// Error carries tracing captured at wrap site.
+type Error struct {
+	// Wrapped error.
+	Err error
+	// Tracing from context at wrap site.
+	// Only ID is set if context carried RequestID.
+	Metadata Metadata
+}
+
+// Wraps err with tracing from context.
+// Returns err as is if it is nil or context has no tracing.
+func WrapError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if m, ok := GetTracing[Metadata](ctx); ok {
+		return &Error{Err: err, Metadata: m}
+	}
+
+	if r, ok := GetTracing[RequestID](ctx); ok {
+		return &Error{Err: err, Metadata: Metadata{ID: string(r)}}
+	}
+
+	return err
+}
+
+// Reads tracing from the first Error in err chain.
+func TracingFromError(err error) (Metadata, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Metadata, true
+	}
+
+	return Metadata{}, false
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,52 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("Error", func() {
+	metadata := tracing.Metadata{ID: "2", CausationID: "1", CorrelationID: "0"}
+
+	It("should attach metadata to error", func() {
+		ctx := tracing.WithTracing(context.Background(), metadata)
+		err := fmt.Errorf("layer: %w", tracing.WrapError(ctx, io.EOF))
+
+		Expect(err).To(MatchError(io.EOF))
+		Expect(err.Error()).To(Equal("layer: EOF"))
+
+		m, ok := tracing.TracingFromError(err)
+
+		Expect(ok).To(BeTrue())
+		Expect(m).To(Equal(metadata))
+
+		var e *tracing.Error
+
+		Expect(errors.As(err, &e)).To(BeTrue())
+		Expect(e.Unwrap()).To(Equal(io.EOF))
+	})
+
+	It("should attach request ID to error", func() {
+		ctx := tracing.WithTracing(context.Background(), tracing.RequestID("1"))
+		m, ok := tracing.TracingFromError(tracing.WrapError(ctx, io.EOF))
+
+		Expect(ok).To(BeTrue())
+		Expect(m).To(Equal(tracing.Metadata{ID: "1"}))
+	})
+
+	It("should return error as is without tracing", func() {
+		Expect(tracing.WrapError(context.Background(), io.EOF)).To(Equal(io.EOF))
+		Expect(tracing.WrapError(context.Background(), nil)).To(BeNil())
+
+		_, ok := tracing.TracingFromError(io.EOF)
+
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Google Cloud trace header name.
+const HeaderCloudTraceContext string = "X-Cloud-Trace-Context"
+
+var (
+	// Metadata reader from Google Cloud trace Header.
+	CloudTraceReadHeader = readCloudTrace
+	// Metadata writer to Google Cloud trace Header.
+	CloudTraceWriteHeader = writeCloudTrace
+	// Metadata options using Google Cloud trace Header.
+	CloudTraceOptions = MetadataOptions(CloudTraceReadHeader, CloudTraceWriteHeader)
+)
+
+// Cloud Logging trace and span ID fields for Metadata.
+// trace is "projects/<projectID>/traces/<trace ID>".
+func CloudLoggingTrace(projectID string, m Metadata) (trace, spanID string) {
+	return "projects/" + projectID + "/traces/" + hexID(m.CorrelationID, 32), hexID(m.ID, 16)
+}
+
+// Maps TRACE_ID to CorrelationID, SPAN_ID to ID and CausationID and TRACE_TRUE to Sampling.
+// Decimal SPAN_ID is converted to 64-bit hex string.
+func readCloudTrace(header http.Header, id string) (Metadata, bool) {
+	v, options, _ := strings.Cut(header.Get(HeaderCloudTraceContext), ";")
+	traceID, spanID, _ := strings.Cut(v, "/")
+	traceID = strings.ToLower(traceID)
+
+	span, err := strconv.ParseUint(spanID, 10, 64)
+	if !isHexID(traceID, 32) || err != nil || span == 0 {
+		return NewMetadata(id), false
+	}
+
+	spanID = hexUint64(span)
+	sampling := SamplingUnknown
+
+	if options == "o=0" || options == "o=1" {
+		sampling = samplingFlag(options == "o=1")
+	}
+
+	return Metadata{ID: spanID, CausationID: spanID, CorrelationID: traceID, Sampling: sampling}, true
+}
+
+// Writes "TRACE_ID/SPAN_ID;o=TRACE_TRUE" with ID as decimal SPAN_ID.
+func writeCloudTrace(header http.Header, m Metadata) {
+	traceTrue := "0"
+	if m.Sampling.Sampled() {
+		traceTrue = "1"
+	}
+
+	header.Set(
+		HeaderCloudTraceContext,
+		hexID(m.CorrelationID, 32)+"/"+strconv.FormatUint(uint64ID(m.ID), 10)+";o="+traceTrue,
+	)
+}
@@ -1,6 +1,6 @@
 module github.com/andriiyaremenko/tracing
 
-go 1.18
+go 1.21
 
 require (
 	github.com/onsi/ginkgo/v2 v2.1.3
@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Starts fn in a new goroutine.
+// fn receives context with next tracing in execution chain.
+func Go(ctx context.Context, getID func() string, fn func(context.Context)) {
+	ctx = nextContext(ctx, getID)
+
+	go fn(ctx)
+}
+
+// Starts fn in a new goroutine.
+// fn receives detached context with next tracing in execution chain.
+// Detached context is never canceled, use it for work that outlives request.
+func GoDetached(ctx context.Context, getID func() string, fn func(context.Context)) {
+	Go(Detach(ctx), getID, fn)
+}
+
+// Returns context that keeps values (including tracing) of parent,
+// but is never canceled and has no deadline.
+func Detach(ctx context.Context) context.Context {
+	return detached{parent: ctx}
+}
+
+// Group is a collection of goroutines working on subtasks of the same task.
+// Every goroutine receives context with next tracing in execution chain.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	getID  func() string
+
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+}
+
+// Creates new Group and derived context.
+// Derived context is canceled the first time function passed to Go returns
+// non-nil error or the first time Wait returns, whichever occurs first.
+func NewGroup(ctx context.Context, getID func() string) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Group{ctx: ctx, cancel: cancel, getID: getID}, ctx
+}
+
+// Creates new Group with detached context.
+// Group work is not canceled together with ctx, but keeps its tracing.
+func NewDetachedGroup(ctx context.Context, getID func() string) (*Group, context.Context) {
+	return NewGroup(Detach(ctx), getID)
+}
+
+// Calls fn in a new goroutine.
+// The first call to return non-nil error cancels the group,
+// its error will be returned by Wait.
+func (g *Group) Go(fn func(context.Context) error) {
+	g.wg.Add(1)
+
+	Go(g.ctx, g.getID, func(ctx context.Context) {
+		defer g.wg.Done()
+
+		if err := fn(ctx); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}
+	})
+}
+
+// Blocks until all function calls from Go have returned,
+// then returns the first non-nil error (if any) from them.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	return g.err
+}
+
+func nextContext(ctx context.Context, getID func() string) context.Context {
+	if m, ok := GetTracing[Metadata](ctx); ok {
+		return WithTracing(ctx, NextMetadata(m, getID()))
+	}
+
+	if r, ok := GetTracing[RequestID](ctx); ok {
+		return WithTracing(ctx, NextRequestID(r, getID()))
+	}
+
+	return ctx
+}
+
+type detached struct {
+	parent context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detached) Done() <-chan struct{}       { return nil }
+func (detached) Err() error                  { return nil }
+
+func (d detached) Value(key any) any {
+	return d.parent.Value(key)
+}
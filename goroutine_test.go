@@ -0,0 +1,115 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("Go", func() {
+	getIDConstructor := func() func() string {
+		var mu sync.Mutex
+		i := 0
+		return func() string {
+			mu.Lock()
+			defer mu.Unlock()
+			defer func() { i++ }()
+
+			return strconv.Itoa(i)
+		}
+	}
+
+	It("should start goroutine with next metadata", func() {
+		ctx := tracing.WithTracing(context.Background(), tracing.Metadata{ID: "b", CausationID: "a", CorrelationID: "a"})
+		result := make(chan tracing.Metadata, 1)
+
+		tracing.Go(ctx, getIDConstructor(), func(ctx context.Context) {
+			m, _ := tracing.GetTracing[tracing.Metadata](ctx)
+			result <- m
+		})
+
+		Eventually(result).Should(Receive(Equal(tracing.Metadata{ID: "0", CausationID: "b", CorrelationID: "a", Hops: 1})))
+	})
+
+	It("should keep request ID", func() {
+		ctx := tracing.WithTracing(context.Background(), tracing.RequestID("a"))
+		result := make(chan tracing.RequestID, 1)
+
+		tracing.Go(ctx, getIDConstructor(), func(ctx context.Context) {
+			r, _ := tracing.GetTracing[tracing.RequestID](ctx)
+			result <- r
+		})
+
+		Eventually(result).Should(Receive(Equal(tracing.RequestID("a"))))
+	})
+
+	It("should keep tracing and drop cancellation for detached goroutine", func() {
+		ctx, cancel := context.WithCancel(
+			tracing.WithTracing(context.Background(), tracing.NewMetadata("a")),
+		)
+		cancel()
+
+		result := make(chan context.Context, 1)
+
+		tracing.GoDetached(ctx, getIDConstructor(), func(ctx context.Context) {
+			result <- ctx
+		})
+
+		var detached context.Context
+		Eventually(result).Should(Receive(&detached))
+
+		m, ok := tracing.GetTracing[tracing.Metadata](detached)
+
+		Expect(ok).To(BeTrue())
+		Expect(m).To(Equal(tracing.Metadata{ID: "0", CausationID: "a", CorrelationID: "a", Hops: 1}))
+		Expect(detached.Err()).ShouldNot(HaveOccurred())
+	})
+
+	Context("Group", func() {
+		It("should derive distinct metadata for every goroutine", func() {
+			ctx := tracing.WithTracing(context.Background(), tracing.NewMetadata("a"))
+			g, _ := tracing.NewGroup(ctx, getIDConstructor())
+
+			var mu sync.Mutex
+			ids := []string{}
+
+			for i := 0; i < 3; i++ {
+				g.Go(func(ctx context.Context) error {
+					m, _ := tracing.GetTracing[tracing.Metadata](ctx)
+
+					mu.Lock()
+					defer mu.Unlock()
+
+					Expect(m.CausationID).To(Equal("a"))
+					Expect(m.CorrelationID).To(Equal("a"))
+					ids = append(ids, m.ID)
+
+					return nil
+				})
+			}
+
+			Expect(g.Wait()).ShouldNot(HaveOccurred())
+			Expect(ids).To(ConsistOf("0", "1", "2"))
+		})
+
+		It("should return first error and cancel context", func() {
+			errTest := errors.New("test")
+			g, ctx := tracing.NewGroup(context.Background(), getIDConstructor())
+
+			g.Go(func(context.Context) error { return errTest })
+			g.Go(func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			})
+
+			Expect(g.Wait()).To(MatchError(errTest))
+			Expect(ctx.Err()).To(MatchError(context.Canceled))
+		})
+	})
+})
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Converts id to lowercase hex string of size characters.
+// All format writers map Metadata IDs through it,
+// so the same Metadata gets the same trace and span IDs in every format.
+// IDs that already are such strings (UUIDs without dashes for 32 characters)
+// are kept as is, 64-bit hex IDs are zero-padded to 128 bits,
+// X-Ray trace IDs are 128-bit IDs without version
+// and other IDs are hashed with SHA-256.
+func hexID(id string, size int) string {
+	if size == 32 && isXRayTraceID(id) {
+		return id[2:10] + id[11:]
+	}
+
+	h := strings.ToLower(strings.ReplaceAll(id, "-", ""))
+
+	if isHexID(h, size) {
+		return h
+	}
+
+	if size == 32 && isHexID(h, 16) {
+		return strings.Repeat("0", 16) + h
+	}
+
+	sum := sha256.Sum256([]byte(id))
+
+	return hex.EncodeToString(sum[:])[:size]
+}
+
+// Converts id to non-zero 64-bit unsigned integer.
+// Uses the same conversion as hexID.
+func uint64ID(id string) uint64 {
+	v, _ := strconv.ParseUint(hexID(id, 16), 16, 64)
+
+	return v
+}
+
+// Formats v as 64-bit hex string.
+func hexUint64(v uint64) string {
+	return fmt.Sprintf("%016x", v)
+}
+
+// Reports whether id is lowercase hex string of size characters that is not all zeros.
+func isHexID(id string, size int) bool {
+	return len(id) == size && isHex(id) && strings.Trim(id, "0") != ""
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Jaeger trace header name.
+	HeaderUberTraceID string = "Uber-Trace-Id"
+	// Jaeger baggage header name prefix.
+	HeaderUberBaggagePrefix string = "Uberctx-"
+)
+
+var (
+	// Metadata reader from Jaeger trace Header.
+	JaegerReadHeader = readJaeger
+	// Metadata writer to Jaeger trace Header.
+	JaegerWriteHeader = writeJaeger
+	// Metadata options using Jaeger trace Header.
+	JaegerOptions = MetadataOptions(JaegerReadHeader, JaegerWriteHeader)
+)
+
+// Reads Jaeger baggage from uberctx-* Headers.
+// Use with WithBaggageHeader to read baggage to request context.
+func JaegerBaggageReadHeader(header http.Header) Baggage {
+	b := Baggage{}
+
+	for name := range header {
+		if !strings.HasPrefix(name, HeaderUberBaggagePrefix) {
+			continue
+		}
+
+		value, err := url.PathUnescape(header.Get(name))
+		if err != nil {
+			continue
+		}
+
+		b[strings.ToLower(strings.TrimPrefix(name, HeaderUberBaggagePrefix))] = value
+	}
+
+	return b
+}
+
+// Writes Jaeger baggage to uberctx-* Headers.
+// Use with WithTransportBaggage to write baggage from request context.
+func JaegerBaggageWriteHeader(header http.Header, b Baggage) {
+	for key, value := range b {
+		header.Set(HeaderUberBaggagePrefix+key, url.PathEscape(value))
+	}
+}
+
+// Maps trace-id to CorrelationID, span-id to ID and parent-span-id to CausationID.
+// CausationID is span-id if parent-span-id is 0.
+// Sampled and debug flags are kept as Sampling.
+func readJaeger(header http.Header, id string) (Metadata, bool) {
+	v, err := url.PathUnescape(header.Get(HeaderUberTraceID))
+	if err != nil {
+		return NewMetadata(id), false
+	}
+
+	parts := strings.Split(v, ":")
+	if len(parts) != 4 {
+		return NewMetadata(id), false
+	}
+
+	traceID, spanID, parentSpanID := padHex(parts[0]), padHex(parts[1]), padHex(parts[2])
+	if !isB3TraceID(traceID) || !isHexID(spanID, 16) {
+		return NewMetadata(id), false
+	}
+
+	if !isHexID(parentSpanID, 16) {
+		parentSpanID = spanID
+	}
+
+	return Metadata{
+		ID:            spanID,
+		CausationID:   parentSpanID,
+		CorrelationID: traceID,
+		Sampling:      jaegerSampling(parts[3]),
+	}, true
+}
+
+// Writes "trace-id:span-id:parent-span-id:flags".
+// parent-span-id is 0 for root events.
+func writeJaeger(header http.Header, m Metadata) {
+	parentSpanID := "0"
+	if m.CausationID != m.ID {
+		parentSpanID = hexID(m.CausationID, 16)
+	}
+
+	header.Set(
+		HeaderUberTraceID,
+		hexID(m.CorrelationID, 32)+":"+hexID(m.ID, 16)+":"+parentSpanID+":"+jaegerFlags(m.Sampling),
+	)
+}
+
+func jaegerSampling(flags string) Sampling {
+	v, err := strconv.ParseUint(flags, 16, 8)
+
+	switch {
+	case err != nil:
+		return SamplingUnknown
+	case v&2 == 2:
+		return SamplingUserKeep
+	default:
+		return samplingFlag(v&1 == 1)
+	}
+}
+
+// Sampled flag is 1, debug flag is 2.
+func jaegerFlags(s Sampling) string {
+	switch {
+	case s == SamplingUserKeep:
+		return "3"
+	case s.Sampled():
+		return "1"
+	default:
+		return "0"
+	}
+}
+
+// Jaeger clients omit leading zeros of IDs.
+func padHex(id string) string {
+	id = strings.ToLower(id)
+
+	switch {
+	case len(id) < 16:
+		return strings.Repeat("0", 16-len(id)) + id
+	case len(id) < 32 && len(id) > 16:
+		return strings.Repeat("0", 32-len(id)) + id
+	default:
+		return id
+	}
+}
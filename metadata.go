@@ -1,6 +1,9 @@
 package tracing
 
-import "net/http"
+import (
+	"net/http"
+	"strconv"
+)
 
 var (
 	// Metadata reader from Header using default Header names.
@@ -31,6 +34,12 @@ type Metadata struct {
 	CorrelationID string
 	// ID of event that caused execution of current event.
 	CausationID string
+	// Number of events in execution chain before current event.
+	// Optional, 0 if not propagated.
+	Hops int
+	// Sampling decision of the trace.
+	// Optional, SamplingUnknown if not propagated.
+	Sampling Sampling
 }
 
 // Creates new Metadata.
@@ -48,6 +57,8 @@ func NextMetadata(m Metadata, id string) Metadata {
 		ID:            id,
 		CausationID:   m.ID,
 		CorrelationID: m.CorrelationID,
+		Hops:          m.Hops + 1,
+		Sampling:      m.Sampling,
 	}
 }
 
@@ -60,12 +71,27 @@ func ValidMetadata(m *Metadata) bool {
 // Will canonicalize provided names.
 func MetadataReadHeader(
 	requestID, causationID, correlationID string,
+) func(header http.Header, id string) (Metadata, bool) {
+	return MetadataReadHeaders(
+		[]string{requestID},
+		[]string{causationID},
+		[]string{correlationID},
+	)
+}
+
+// Metadata reader from Header using provided ordered lists of Header names.
+// Every field is read from the first present Header in its list.
+// Hops are read from HeaderHopCount if present.
+// Will canonicalize provided names.
+func MetadataReadHeaders(
+	requestIDs, causationIDs, correlationIDs []string,
 ) func(header http.Header, id string) (Metadata, bool) {
 	return func(header http.Header, id string) (Metadata, bool) {
 		m := Metadata{
-			ID:            header.Get(requestID),
-			CorrelationID: header.Get(correlationID),
-			CausationID:   header.Get(causationID),
+			ID:            firstHeader(header, requestIDs),
+			CorrelationID: firstHeader(header, correlationIDs),
+			CausationID:   firstHeader(header, causationIDs),
+			Hops:          readHops(header),
 		}
 
 		if ValidMetadata(&m) {
@@ -77,6 +103,7 @@ func MetadataReadHeader(
 }
 
 // Metadata writer to Header using provided Header names.
+// Hops are written to HeaderHopCount if not 0.
 // Will canonicalize provided names.
 func MetadataWriteHeader(
 	requestID, causationID, correlationID string,
@@ -85,6 +112,10 @@ func MetadataWriteHeader(
 		header.Set(requestID, m.ID)
 		header.Set(causationID, m.CausationID)
 		header.Set(correlationID, m.CorrelationID)
+
+		if m.Hops > 0 {
+			header.Set(HeaderHopCount, strconv.Itoa(m.Hops))
+		}
 	}
 }
 
@@ -105,3 +136,12 @@ func MetadataOptionsWithHeader(requestID, causationID, correlationID string) Opt
 		MetadataWriteHeader(requestID, causationID, correlationID),
 	)
 }
+
+func readHops(header http.Header) int {
+	hops, err := strconv.Atoi(header.Get(HeaderHopCount))
+	if err != nil || hops < 0 {
+		return 0
+	}
+
+	return hops
+}
@@ -236,4 +236,35 @@ var _ = Describe("Metadata", func() {
 			resp.Body.Close()
 		})
 	})
+
+	Context("should read Metadata fields from the first present header alias", func() {
+		read := tracing.MetadataReadHeaders(
+			[]string{"X-Request-Id", "Request-Id"},
+			[]string{"X-Causation-Id", "Causation-Id"},
+			[]string{"X-Correlation-Id", "Correlation-Id"},
+		)
+
+		It("in order of aliases", func() {
+			header := http.Header{}
+			header.Set("Request-Id", "2")
+			header.Set("X-Causation-Id", "1")
+			header.Set("Causation-Id", "3")
+			header.Set("Correlation-Id", "0")
+
+			metadata, ok := read(header, "4")
+
+			Expect(ok).To(BeTrue())
+			Expect(metadata).To(Equal(tracing.Metadata{ID: "2", CausationID: "1", CorrelationID: "0"}))
+		})
+
+		It("or return new Metadata if any field is missing", func() {
+			header := http.Header{}
+			header.Set("Request-Id", "2")
+
+			metadata, ok := read(header, "4")
+
+			Expect(ok).To(BeFalse())
+			Expect(metadata).To(Equal(tracing.NewMetadata("4")))
+		})
+	})
 })
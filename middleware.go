@@ -3,6 +3,7 @@ package tracing
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 )
 
 const (
@@ -12,6 +13,10 @@ const (
 	HeaderCausationID string = "X-Causation-Id"
 	// Default CorrelationID header name.
 	HeaderCorrelationID string = "X-Correlation-Id"
+	// Hop count header name.
+	HeaderHopCount string = "X-Hop-Count"
+	// Retry attempt header name.
+	HeaderRetryAttempt string = "X-Retry-Attempt"
 )
 
 // Tracing reader from Header.
@@ -35,24 +40,74 @@ func FromStringer(newStringer func() fmt.Stringer) func() string {
 
 // Tracing middleware.
 // Reads Tracing headers and writes next Tracing to Header and context.
+// Metadata hop count is read from HeaderHopCount whatever Options are used.
+// Baggage is read to context if configured with WithBaggageHeader.
 func Middleware[T Metadata | RequestID, Opts Options[T]](
 	opts Opts,
 	getID func() string,
+	options ...MiddlewareOption,
 ) func(http.Handler) http.Handler {
+	cfg := newMiddlewareConfig(options)
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if cfg.skip(req) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
 			read, write, nextT := opts()
 			id := getID()
 			t, ok := read(req.Header, id)
 			if ok {
-				t = nextT(t, id)
+				t = nextT(withHopCount(t, req.Header), id)
+			}
+
+			if cfg.echo {
+				header := http.Header{}
+
+				write(header, t)
+				cfg.writeResponseHeader(w.Header(), header)
+			}
+
+			if cfg.tooManyHops(t) {
+				http.Error(w, http.StatusText(http.StatusLoopDetected), http.StatusLoopDetected)
+				return
 			}
 
-			ctx := req.Context()
-			ctx = WithTracing(ctx, t)
+			ctx, cancel := cfg.withDeadline(req.Context(), req)
+			defer cancel()
+
+			ctx = WithTracing(cfg.withBaggage(ctx, req), t)
+
+			if attempt, err := strconv.Atoi(req.Header.Get(HeaderRetryAttempt)); err == nil && attempt > 0 {
+				ctx = WithRetryAttempt(ctx, attempt)
+			}
 
-			write(w.Header(), t)
 			next.ServeHTTP(w, req.Clone(ctx))
 		})
 	}
 }
+
+// Sets Metadata hop count from HeaderHopCount if it was not read with tracing.
+func withHopCount[T Metadata | RequestID](t T, header http.Header) T {
+	m, ok := any(t).(Metadata)
+	if !ok || m.Hops != 0 {
+		return t
+	}
+
+	m.Hops = readHops(header)
+
+	return any(m).(T)
+}
+
+// Returns value of the first present Header from names.
+func firstHeader(header http.Header, names []string) string {
+	for _, name := range names {
+		if v := header.Get(name); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
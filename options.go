@@ -0,0 +1,222 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Middleware option.
+type MiddlewareOption func(*middlewareConfig)
+
+type middlewareConfig struct {
+	echo        bool
+	echoHeaders map[string]bool
+	rename      map[string]string
+	expose      bool
+	skips       []func(*http.Request) bool
+
+	deadlineHeader string
+	maxTimeout     time.Duration
+
+	maxHops int
+
+	baggage []func(http.Header) Baggage
+}
+
+func newMiddlewareConfig(options []MiddlewareOption) *middlewareConfig {
+	cfg := &middlewareConfig{echo: true, rename: map[string]string{}}
+
+	for _, option := range options {
+		option(cfg)
+	}
+
+	return cfg
+}
+
+// Disables writing tracing headers to response.
+func WithoutEcho() MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.echo = false
+	}
+}
+
+// Writes only provided tracing headers to response.
+// Can be used to keep internal causation IDs from leaking to public API clients.
+// Will canonicalize provided names.
+func WithEchoHeaders(names ...string) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		if cfg.echoHeaders == nil {
+			cfg.echoHeaders = map[string]bool{}
+		}
+
+		for _, name := range names {
+			cfg.echoHeaders[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+}
+
+// Writes tracing header name to response as to.
+// Will canonicalize provided names.
+func WithEchoRename(name, to string) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.rename[http.CanonicalHeaderKey(name)] = http.CanonicalHeaderKey(to)
+	}
+}
+
+// Lists tracing headers written to response in Access-Control-Expose-Headers,
+// so they are readable by browser clients.
+func WithExposeHeaders() MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.expose = true
+	}
+}
+
+// Serves requests matching predicate without tracing.
+func WithSkip(predicate func(*http.Request) bool) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.skips = append(cfg.skips, predicate)
+	}
+}
+
+// Serves requests with path matching any of glob patterns without tracing.
+// Patterns use path.Match syntax, e.g. "/healthz" or "/static/*.css".
+func WithSkipPaths(patterns ...string) MiddlewareOption {
+	return WithSkip(func(req *http.Request) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, req.URL.Path); ok {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Serves requests with path starting with any of prefixes without tracing.
+func WithSkipPrefixes(prefixes ...string) MiddlewareOption {
+	return WithSkip(func(req *http.Request) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(req.URL.Path, prefix) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Serves requests with any of methods without tracing.
+func WithSkipMethods(methods ...string) MiddlewareOption {
+	return WithSkip(func(req *http.Request) bool {
+		for _, method := range methods {
+			if req.Method == method {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Applies remaining time read from header to request context deadline.
+// Timeout is capped by max if it is positive.
+func WithDeadline(header string, max time.Duration) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.deadlineHeader = header
+		cfg.maxTimeout = max
+	}
+}
+
+// Rejects requests with Metadata hop count exceeding max with 508 Loop Detected.
+// Can be used to break request loops between services.
+func WithMaxHops(max int) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.maxHops = max
+	}
+}
+
+// Reads baggage from request Header to context with read.
+// Can be provided several times, baggage read by every reader is merged.
+func WithBaggageHeader(read func(http.Header) Baggage) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.baggage = append(cfg.baggage, read)
+	}
+}
+
+func (cfg *middlewareConfig) tooManyHops(t any) bool {
+	m, ok := t.(Metadata)
+
+	return ok && cfg.maxHops > 0 && m.Hops > cfg.maxHops
+}
+
+func (cfg *middlewareConfig) withDeadline(
+	ctx context.Context,
+	req *http.Request,
+) (context.Context, context.CancelFunc) {
+	if cfg.deadlineHeader == "" {
+		return ctx, func() {}
+	}
+
+	timeout, ok := parseTimeout(req.Header.Get(cfg.deadlineHeader))
+	if !ok {
+		return ctx, func() {}
+	}
+
+	if cfg.maxTimeout > 0 && timeout > cfg.maxTimeout {
+		timeout = cfg.maxTimeout
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (cfg *middlewareConfig) withBaggage(ctx context.Context, req *http.Request) context.Context {
+	b := Baggage{}
+
+	for _, read := range cfg.baggage {
+		for key, value := range read(req.Header) {
+			b[key] = value
+		}
+	}
+
+	if len(b) == 0 {
+		return ctx
+	}
+
+	return WithBaggage(ctx, b)
+}
+
+func (cfg *middlewareConfig) skip(req *http.Request) bool {
+	for _, skip := range cfg.skips {
+		if skip(req) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cfg *middlewareConfig) writeResponseHeader(dst, src http.Header) {
+	names := make([]string, 0, len(src))
+
+	for name, values := range src {
+		if cfg.echoHeaders != nil && !cfg.echoHeaders[name] {
+			continue
+		}
+
+		if to, ok := cfg.rename[name]; ok {
+			name = to
+		}
+
+		dst[name] = values
+		names = append(names, name)
+	}
+
+	if cfg.expose && len(names) != 0 {
+		sort.Strings(names)
+		dst.Add("Access-Control-Expose-Headers", strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,138 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("MiddlewareOption", func() {
+	serve := func(options ...tracing.MiddlewareOption) (http.Header, tracing.Metadata) {
+		var metadata tracing.Metadata
+
+		recorder := httptest.NewRecorder()
+		middleware := tracing.Middleware(tracing.DefaultMetadataOptions, func() string { return "0" }, options...)
+		middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			metadata, _ = tracing.GetTracing[tracing.Metadata](r.Context())
+		})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		return recorder.Header(), metadata
+	}
+
+	It("should not echo headers", func() {
+		header, metadata := serve(tracing.WithoutEcho())
+
+		Expect(header).To(BeEmpty())
+		Expect(metadata).To(Equal(tracing.NewMetadata("0")))
+	})
+
+	It("should echo only provided headers", func() {
+		header, _ := serve(tracing.WithEchoHeaders("x-request-id"))
+
+		Expect(header).To(Equal(http.Header{tracing.HeaderRequestID: []string{"0"}}))
+	})
+
+	It("should rename headers", func() {
+		header, _ := serve(
+			tracing.WithEchoHeaders(tracing.HeaderRequestID),
+			tracing.WithEchoRename(tracing.HeaderRequestID, "Request-Id"),
+		)
+
+		Expect(header).To(Equal(http.Header{"Request-Id": []string{"0"}}))
+	})
+
+	It("should expose headers", func() {
+		header, _ := serve(tracing.WithExposeHeaders())
+
+		Expect(header).To(HaveKeyWithValue(
+			"Access-Control-Expose-Headers",
+			[]string{"X-Causation-Id, X-Correlation-Id, X-Request-Id"},
+		))
+	})
+
+	Context("skip", func() {
+		serveRequest := func(method, target string, options ...tracing.MiddlewareOption) bool {
+			calls := 0
+			found := false
+
+			middleware := tracing.Middleware(
+				tracing.DefaultMetadataOptions,
+				func() string {
+					calls++
+					return "0"
+				},
+				options...,
+			)
+			middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, found = tracing.GetTracing[tracing.Metadata](r.Context())
+			})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, target, nil))
+
+			Expect(found).To(Equal(calls == 1))
+
+			return found
+		}
+
+		It("should skip paths matching glob", func() {
+			option := tracing.WithSkipPaths("/healthz", "/static/*.css")
+
+			Expect(serveRequest(http.MethodGet, "/healthz", option)).To(BeFalse())
+			Expect(serveRequest(http.MethodGet, "/static/main.css", option)).To(BeFalse())
+			Expect(serveRequest(http.MethodGet, "/static/main.js", option)).To(BeTrue())
+		})
+
+		It("should skip paths with prefix", func() {
+			option := tracing.WithSkipPrefixes("/metrics")
+
+			Expect(serveRequest(http.MethodGet, "/metrics/go", option)).To(BeFalse())
+			Expect(serveRequest(http.MethodGet, "/api", option)).To(BeTrue())
+		})
+
+		It("should skip methods and predicates", func() {
+			options := []tracing.MiddlewareOption{
+				tracing.WithSkipMethods(http.MethodOptions),
+				tracing.WithSkip(func(r *http.Request) bool { return r.Header.Get("Upgrade") != "" }),
+			}
+
+			Expect(serveRequest(http.MethodOptions, "/", options...)).To(BeFalse())
+			Expect(serveRequest(http.MethodGet, "/", options...)).To(BeTrue())
+		})
+	})
+
+	Context("max hops", func() {
+		middleware := tracing.Middleware(tracing.DefaultMetadataOptions, func() string { return "3" }, tracing.WithMaxHops(2))
+		serveHops := func(hops int) (*httptest.ResponseRecorder, bool) {
+			called := false
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			tracing.DefaultMetadataWriteHeader(
+				req.Header,
+				tracing.Metadata{ID: "2", CausationID: "1", CorrelationID: "0", Hops: hops},
+			)
+			middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})).ServeHTTP(recorder, req)
+
+			return recorder, called
+		}
+
+		It("should serve requests within hop limit", func() {
+			recorder, called := serveHops(1)
+
+			Expect(called).To(BeTrue())
+			Expect(recorder.Header()).To(HaveKeyWithValue(tracing.HeaderHopCount, []string{"2"}))
+		})
+
+		It("should reject requests exceeding hop limit", func() {
+			recorder, called := serveHops(2)
+
+			Expect(called).To(BeFalse())
+			Expect(recorder.Code).To(Equal(http.StatusLoopDetected))
+			Expect(recorder.Header()).To(HaveKeyWithValue(tracing.HeaderHopCount, []string{"3"}))
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// memoryConnector is in-memory stand-in for database that understands outbox queries only.
+type memoryConnector struct {
+	mu      sync.Mutex
+	rows    []memoryRow
+	queries []string
+}
+
+type memoryRow struct {
+	id        int64
+	topic     string
+	payload   []byte
+	metadata  string
+	published bool
+}
+
+func (c *memoryConnector) Connect(context.Context) (driver.Conn, error) {
+	return &memoryConn{db: c}, nil
+}
+
+func (c *memoryConnector) Driver() driver.Driver {
+	return nil
+}
+
+type memoryConn struct {
+	db *memoryConnector
+}
+
+func (c *memoryConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare is not supported")
+}
+
+func (c *memoryConn) Close() error { return nil }
+
+func (c *memoryConn) Begin() (driver.Tx, error) { return memoryTx{}, nil }
+
+func (c *memoryConn) ExecContext(
+	_ context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "INSERT"):
+		c.db.rows = append(c.db.rows, memoryRow{
+			id:       int64(len(c.db.rows) + 1),
+			topic:    args[0].Value.(string),
+			payload:  args[1].Value.([]byte),
+			metadata: args[2].Value.(string),
+		})
+
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(query, "UPDATE"):
+		id := args[1].Value.(int64)
+		c.db.rows[id-1].published = true
+
+		return driver.RowsAffected(1), nil
+	default:
+		return nil, fmt.Errorf("unexpected exec %q", query)
+	}
+}
+
+func (c *memoryConn) QueryContext(
+	_ context.Context,
+	query string,
+	_ []driver.NamedValue,
+) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	if !strings.HasPrefix(query, "SELECT") {
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+
+	c.db.queries = append(c.db.queries, query)
+
+	rows := &memoryRows{}
+	for _, r := range c.db.rows {
+		if !r.published {
+			rows.rows = append(rows.rows, r)
+		}
+	}
+
+	return rows, nil
+}
+
+type memoryTx struct{}
+
+func (memoryTx) Commit() error   { return nil }
+func (memoryTx) Rollback() error { return nil }
+
+type memoryRows struct {
+	rows []memoryRow
+}
+
+func (r *memoryRows) Columns() []string {
+	return []string{"id", "topic", "payload", "metadata"}
+}
+
+func (r *memoryRows) Close() error { return nil }
+
+func (r *memoryRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	dest[0] = r.rows[0].id
+	dest[1] = r.rows[0].topic
+	dest[2] = r.rows[0].payload
+	dest[3] = r.rows[0].metadata
+	r.rows = r.rows[1:]
+
+	return nil
+}
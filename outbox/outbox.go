@@ -0,0 +1,246 @@
+// This package provides transactional outbox that preserves tracing.
+// Events are written in the same transaction as business data
+// with next Metadata in execution chain and relayed to publisher
+// with stored Metadata restored into context.
+//
+// Expected table schema (adjust types to your database):
+//
+//	CREATE TABLE outbox (
+//		id           INTEGER PRIMARY KEY AUTOINCREMENT,
+//		topic        TEXT NOT NULL,
+//		payload      BLOB NOT NULL,
+//		metadata     TEXT NOT NULL,
+//		published_at TIMESTAMP NULL
+//	);
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+// Default number of events relayed in one batch.
+const DefaultBatchSize int = 100
+
+// Query placeholder for n-th (starting from 1) argument.
+type Placeholder func(n int) string
+
+// Placeholder used by MySQL and SQLite.
+func QuestionPlaceholder(int) string {
+	return "?"
+}
+
+// Placeholder used by PostgreSQL.
+func DollarPlaceholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+// Event stored in outbox.
+type Event struct {
+	// Outbox row ID.
+	ID int64
+	// Event topic.
+	Topic string
+	// Event payload.
+	Payload []byte
+	// Event tracing.
+	Metadata tracing.Metadata
+}
+
+// Outbox writes and relays events stored in table.
+// Should be created with New, Outbox without ID generator can only relay events.
+type Outbox struct {
+	// Outbox table name.
+	Table string
+	// Query placeholder.
+	// QuestionPlaceholder is used if nil.
+	Placeholder Placeholder
+	// Number of events relayed in one batch.
+	// DefaultBatchSize is used if not positive.
+	BatchSize int
+	// Locks pending rows with FOR UPDATE SKIP LOCKED (PostgreSQL, MySQL 8).
+	// Without row locks concurrent relays publish the same events.
+	SkipLocked bool
+
+	getID func() string
+}
+
+// Creates new Outbox using table with question placeholders.
+func New(table string, getID func() string) *Outbox {
+	return &Outbox{
+		Table:       table,
+		Placeholder: QuestionPlaceholder,
+		BatchSize:   DefaultBatchSize,
+		getID:       getID,
+	}
+}
+
+// Writes event to outbox inside tx.
+// Event Metadata is next Metadata in execution chain of ctx,
+// or new Metadata if ctx has no tracing.
+func (o *Outbox) Write(ctx context.Context, tx *sql.Tx, topic string, payload []byte) error {
+	if o.getID == nil {
+		return errors.New("outbox: write event: Outbox is not created with New")
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (topic, payload, metadata) VALUES (%s, %s, %s)",
+		o.Table,
+		o.placeholder(1),
+		o.placeholder(2),
+		o.placeholder(3),
+	)
+
+	if _, err := tx.ExecContext(ctx, query, topic, payload, nextMetadata(ctx, o.getID())); err != nil {
+		return fmt.Errorf("outbox: write event: %w", err)
+	}
+
+	return nil
+}
+
+// Relays one batch of pending events to publish in order they were written.
+// publish receives context with event Metadata.
+// Relay stops at the first publish error, events published before it are marked as published.
+// Relay takes no row locks unless SkipLocked is set,
+// so only one relay should run at a time without it.
+// Returns number of published events.
+func (o *Outbox) Relay(
+	ctx context.Context,
+	db *sql.DB,
+	publish func(context.Context, Event) error,
+) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("outbox: begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	events, err := o.pending(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET published_at = %s WHERE id = %s",
+		o.Table,
+		o.placeholder(1),
+		o.placeholder(2),
+	)
+
+	published := 0
+
+	var publishErr error
+	for _, e := range events {
+		if publishErr = publish(tracing.WithTracing(ctx, e.Metadata), e); publishErr != nil {
+			publishErr = fmt.Errorf("outbox: publish event %d: %w", e.ID, publishErr)
+			break
+		}
+
+		if _, err := tx.ExecContext(ctx, query, time.Now().UTC(), e.ID); err != nil {
+			return 0, fmt.Errorf("outbox: mark event %d published: %w", e.ID, err)
+		}
+
+		published++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("outbox: commit transaction: %w", err)
+	}
+
+	return published, publishErr
+}
+
+// Relays pending events every interval until ctx is done.
+// Relay errors are reported to onError (if not nil) and relaying continues on next tick.
+// Returns ctx error.
+func (o *Outbox) Run(
+	ctx context.Context,
+	db *sql.DB,
+	interval time.Duration,
+	publish func(context.Context, Event) error,
+	onError func(error),
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := o.Relay(ctx, db, publish); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *Outbox) pending(ctx context.Context, tx *sql.Tx) ([]Event, error) {
+	query := fmt.Sprintf(
+		"SELECT id, topic, payload, metadata FROM %s WHERE published_at IS NULL ORDER BY id LIMIT %d",
+		o.Table,
+		o.batchSize(),
+	)
+
+	if o.SkipLocked {
+		query += " FOR UPDATE SKIP LOCKED"
+	}
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("outbox: read pending events: %w", err)
+	}
+
+	defer rows.Close()
+
+	events := []Event{}
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.ID, &e.Topic, &e.Payload, &e.Metadata); err != nil {
+			return nil, fmt.Errorf("outbox: read pending events: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("outbox: read pending events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (o *Outbox) placeholder(n int) string {
+	if o.Placeholder == nil {
+		return QuestionPlaceholder(n)
+	}
+
+	return o.Placeholder(n)
+}
+
+func (o *Outbox) batchSize() int {
+	if o.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+
+	return o.BatchSize
+}
+
+func nextMetadata(ctx context.Context, id string) tracing.Metadata {
+	if m, ok := tracing.GetTracing[tracing.Metadata](ctx); ok {
+		return tracing.NextMetadata(m, id)
+	}
+
+	if r, ok := tracing.GetTracing[tracing.RequestID](ctx); ok {
+		return tracing.NextMetadata(tracing.NewMetadata(string(r)), id)
+	}
+
+	return tracing.NewMetadata(id)
+}
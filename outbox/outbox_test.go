@@ -0,0 +1,173 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+	"github.com/andriiyaremenko/tracing/outbox"
+)
+
+var _ = Describe("Outbox", func() {
+	getIDConstructor := func() func() string {
+		i := 0
+		return func() string {
+			defer func() { i++ }()
+
+			return strconv.Itoa(i)
+		}
+	}
+
+	var (
+		memory *memoryConnector
+		db     *sql.DB
+		o      *outbox.Outbox
+	)
+
+	BeforeEach(func() {
+		memory = &memoryConnector{}
+		db = sql.OpenDB(memory)
+		o = outbox.New("outbox", getIDConstructor())
+	})
+
+	AfterEach(func() {
+		db.Close()
+	})
+
+	write := func(ctx context.Context, topic string) {
+		tx, err := db.BeginTx(ctx, nil)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(o.Write(ctx, tx, topic, []byte(topic))).ShouldNot(HaveOccurred())
+		Expect(tx.Commit()).ShouldNot(HaveOccurred())
+	}
+
+	It("should relay events with next metadata in context", func() {
+		ctx := tracing.WithTracing(
+			context.Background(),
+			tracing.Metadata{ID: "b", CausationID: "a", CorrelationID: "a"},
+		)
+
+		write(ctx, "first")
+		write(context.Background(), "second")
+
+		relayed := []tracing.Metadata{}
+		n, err := o.Relay(context.Background(), db, func(ctx context.Context, e outbox.Event) error {
+			m, ok := tracing.GetTracing[tracing.Metadata](ctx)
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(e.Metadata))
+
+			relayed = append(relayed, m)
+
+			return nil
+		})
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(n).To(Equal(2))
+		Expect(relayed).To(Equal([]tracing.Metadata{
+			{ID: "0", CausationID: "b", CorrelationID: "a", Hops: 1},
+			tracing.NewMetadata("1"),
+		}))
+
+		n, err = o.Relay(context.Background(), db, func(context.Context, outbox.Event) error {
+			Fail("event was relayed twice")
+			return nil
+		})
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(n).To(BeZero())
+	})
+
+	It("should stop at publish error and keep failed events pending", func() {
+		errPublish := errors.New("publish")
+
+		write(context.Background(), "first")
+		write(context.Background(), "second")
+
+		n, err := o.Relay(context.Background(), db, func(_ context.Context, e outbox.Event) error {
+			if e.Topic == "second" {
+				return errPublish
+			}
+
+			return nil
+		})
+
+		Expect(err).To(MatchError(errPublish))
+		Expect(n).To(Equal(1))
+
+		topics := []string{}
+		_, err = o.Relay(context.Background(), db, func(_ context.Context, e outbox.Event) error {
+			topics = append(topics, e.Topic)
+			return nil
+		})
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(topics).To(Equal([]string{"second"}))
+	})
+
+	It("should keep relaying after publish error until context is done", func() {
+		errPublish := errors.New("publish")
+
+		write(context.Background(), "first")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		errs := make(chan error, 10)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- o.Run(
+				ctx,
+				db,
+				time.Millisecond,
+				func(context.Context, outbox.Event) error {
+					attempts++
+					if attempts < 3 {
+						return errPublish
+					}
+
+					cancel()
+
+					return nil
+				},
+				func(err error) { errs <- err },
+			)
+		}()
+
+		Eventually(done).Should(Receive(MatchError(context.Canceled)))
+		Expect(attempts).To(Equal(3))
+		Expect(errs).To(HaveLen(2))
+		Expect(<-errs).To(MatchError(errPublish))
+	})
+
+	It("should use defaults for zero value", func() {
+		zero := &outbox.Outbox{Table: "outbox"}
+
+		_, err := zero.Relay(context.Background(), db, func(context.Context, outbox.Event) error { return nil })
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(memory.queries).To(ConsistOf(HaveSuffix(" LIMIT 100")))
+
+		tx, err := db.BeginTx(context.Background(), nil)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(zero.Write(context.Background(), tx, "topic", nil)).To(HaveOccurred())
+		Expect(tx.Rollback()).ShouldNot(HaveOccurred())
+	})
+
+	It("should lock pending rows if configured", func() {
+		o.SkipLocked = true
+
+		_, err := o.Relay(context.Background(), db, func(context.Context, outbox.Event) error { return nil })
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(memory.queries).To(ConsistOf(HaveSuffix(" FOR UPDATE SKIP LOCKED")))
+	})
+})
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"runtime/pprof"
+)
+
+const (
+	// RequestID profiler label name.
+	LabelRequestID string = "request_id"
+	// CausationID profiler label name.
+	LabelCausationID string = "causation_id"
+	// CorrelationID profiler label name.
+	LabelCorrelationID string = "correlation_id"
+)
+
+// Profiler labels from tracing in context.
+func ProfilerLabels(ctx context.Context) pprof.LabelSet {
+	if m, ok := GetTracing[Metadata](ctx); ok {
+		return pprof.Labels(
+			LabelRequestID, m.ID,
+			LabelCausationID, m.CausationID,
+			LabelCorrelationID, m.CorrelationID,
+		)
+	}
+
+	if r, ok := GetTracing[RequestID](ctx); ok {
+		return pprof.Labels(LabelRequestID, string(r))
+	}
+
+	return pprof.Labels()
+}
+
+// Calls fn with profiler labels from tracing in context.
+// Goroutines started by fn inherit labels.
+func DoWithProfilerLabels(ctx context.Context, fn func(context.Context)) {
+	pprof.Do(ctx, ProfilerLabels(ctx), fn)
+}
+
+// Profiler middleware.
+// Serves request with profiler labels from tracing in request context.
+// Should be used after Middleware.
+func ProfilerMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		DoWithProfilerLabels(req.Context(), func(ctx context.Context) {
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime/pprof"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("Profiler", func() {
+	getLabels := func(ctx context.Context) map[string]string {
+		labels := map[string]string{}
+
+		pprof.ForLabels(ctx, func(key, value string) bool {
+			labels[key] = value
+			return true
+		})
+
+		return labels
+	}
+
+	It("should label metadata", func() {
+		ctx := tracing.WithTracing(
+			context.Background(),
+			tracing.Metadata{ID: "2", CausationID: "1", CorrelationID: "0"},
+		)
+
+		tracing.DoWithProfilerLabels(ctx, func(ctx context.Context) {
+			defer GinkgoRecover()
+
+			Expect(getLabels(ctx)).To(Equal(map[string]string{
+				tracing.LabelRequestID:     "2",
+				tracing.LabelCausationID:   "1",
+				tracing.LabelCorrelationID: "0",
+			}))
+		})
+	})
+
+	It("should label request ID", func() {
+		ctx := tracing.WithTracing(context.Background(), tracing.RequestID("1"))
+
+		tracing.DoWithProfilerLabels(ctx, func(ctx context.Context) {
+			defer GinkgoRecover()
+
+			Expect(getLabels(ctx)).To(Equal(map[string]string{tracing.LabelRequestID: "1"}))
+		})
+	})
+
+	It("should label request context in middleware", func() {
+		middleware := tracing.Middleware(tracing.DefaultMetadataOptions, func() string { return "0" })
+		called := false
+		handler := middleware(tracing.ProfilerMiddleware(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+
+				Expect(getLabels(r.Context())).To(HaveKeyWithValue(tracing.LabelCorrelationID, "0"))
+			}),
+		))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(called).To(BeTrue())
+	})
+})
@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Problem Details media type.
+const ContentTypeProblemJSON string = "application/problem+json"
+
+// Problem Details for HTTP APIs (RFC 7807).
+// Can be returned as error from ErrorHandlerFunc.
+type Problem struct {
+	// URI reference that identifies problem type.
+	Type string `json:"type,omitempty"`
+	// Short human-readable summary of problem type.
+	Title string `json:"title,omitempty"`
+	// HTTP status code.
+	Status int `json:"status,omitempty"`
+	// Human-readable explanation specific to this occurrence of problem.
+	Detail string `json:"detail,omitempty"`
+	// URI reference that identifies specific occurrence of problem.
+	Instance string `json:"instance,omitempty"`
+	// Tracing ID extension.
+	TraceID string `json:"traceId,omitempty"`
+	// Root event ID extension.
+	CorrelationID string `json:"correlationId,omitempty"`
+}
+
+// Creates new Problem with status title.
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+
+	return p.Title
+}
+
+// Writes Problem as application/problem+json.
+// Fills Instance, TraceID and CorrelationID from request and its context if not set.
+func WriteProblem(w http.ResponseWriter, req *http.Request, p Problem) {
+	requestID, _, correlationID := tracingIDs(req.Context())
+
+	if p.Status == 0 {
+		p.Status = http.StatusInternalServerError
+	}
+
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+
+	if p.Instance == "" {
+		p.Instance = req.URL.RequestURI()
+	}
+
+	if p.TraceID == "" {
+		p.TraceID = requestID
+	}
+
+	if p.CorrelationID == "" {
+		p.CorrelationID = correlationID
+	}
+
+	w.Header().Set("Content-Type", ContentTypeProblemJSON)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Handler that returns error.
+type ErrorHandlerFunc func(http.ResponseWriter, *http.Request) error
+
+// Converts ErrorHandlerFunc into http.Handler.
+// Returned errors are passed to onError and written as Problem.
+// Errors that are not Problem are written as 500 without detail.
+// Errors are not written if handler has already written response.
+// Should be used after Middleware to include tracing.
+func ProblemHandler(h ErrorHandlerFunc, onError func(*http.Request, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rw := newResponseWriter(w)
+
+		err := h(rw, req)
+		if err == nil {
+			return
+		}
+
+		onError(req, err)
+
+		if rw.wroteHeader {
+			return
+		}
+
+		var p *Problem
+		if errors.As(err, &p) {
+			WriteProblem(w, req, *p)
+			return
+		}
+
+		WriteProblem(w, req, Problem{Status: http.StatusInternalServerError})
+	})
+}
@@ -0,0 +1,103 @@
+package tracing_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("Problem", func() {
+	middleware := tracing.Middleware(tracing.DefaultMetadataOptions, func() string { return "0" })
+	var reported []error
+
+	BeforeEach(func() {
+		reported = nil
+	})
+
+	serve := func(h tracing.ErrorHandlerFunc, req *http.Request) (*httptest.ResponseRecorder, tracing.Problem) {
+		recorder := httptest.NewRecorder()
+		onError := func(r *http.Request, err error) {
+			reported = append(reported, err)
+		}
+
+		middleware(tracing.ProblemHandler(h, onError)).ServeHTTP(recorder, req)
+
+		var p tracing.Problem
+		_ = json.Unmarshal(recorder.Body.Bytes(), &p)
+
+		return recorder, p
+	}
+
+	It("should write problem returned from handler", func() {
+		recorder, p := serve(
+			func(w http.ResponseWriter, r *http.Request) error {
+				return fmt.Errorf("wrapped: %w", tracing.NewProblem(http.StatusNotFound, "no such item"))
+			},
+			httptest.NewRequest(http.MethodGet, "/items/1", nil),
+		)
+
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal(tracing.ContentTypeProblemJSON))
+		Expect(p).To(Equal(tracing.Problem{
+			Title:         "Not Found",
+			Status:        http.StatusNotFound,
+			Detail:        "no such item",
+			Instance:      "/items/1",
+			TraceID:       "0",
+			CorrelationID: "0",
+		}))
+		Expect(reported).To(HaveLen(1))
+	})
+
+	It("should hide details of other errors", func() {
+		recorder, p := serve(
+			func(w http.ResponseWriter, r *http.Request) error { return errors.New("secret") },
+			httptest.NewRequest(http.MethodGet, "/", nil),
+		)
+
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		Expect(p.Detail).To(BeEmpty())
+		Expect(p.TraceID).To(Equal("0"))
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0]).To(MatchError("secret"))
+	})
+
+	It("should not write problem for nil error", func() {
+		recorder, _ := serve(
+			func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusNoContent)
+				return nil
+			},
+			httptest.NewRequest(http.MethodGet, "/", nil),
+		)
+
+		Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		Expect(recorder.Body.String()).To(BeEmpty())
+		Expect(reported).To(BeEmpty())
+	})
+
+	It("should not write problem after response was written", func() {
+		recorder, _ := serve(
+			func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte("partial"))
+
+				return errors.New("failed")
+			},
+			httptest.NewRequest(http.MethodGet, "/", nil),
+		)
+
+		Expect(recorder.Code).To(Equal(http.StatusAccepted))
+		Expect(recorder.Header().Get("Content-Type")).NotTo(Equal(tracing.ContentTypeProblemJSON))
+		Expect(recorder.Body.String()).To(Equal("partial"))
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0]).To(MatchError("failed"))
+	})
+})
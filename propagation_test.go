@@ -0,0 +1,603 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+	"github.com/andriiyaremenko/tracing/tracingtest"
+)
+
+var _ = Describe("Propagation", func() {
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+		spanID   = "b7ad6b7169203331"
+	)
+
+	Context("W3C traceparent", func() {
+		It("should read traceparent", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderTraceParent, "00-"+traceID+"-"+parentID+"-01")
+
+			m, ok := tracing.TraceParentReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{
+				ID:            parentID,
+				CausationID:   parentID,
+				CorrelationID: traceID,
+				Sampling:      tracing.SamplingKeep,
+			}))
+		})
+
+		It("should keep sampled flag", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderTraceParent, "00-"+traceID+"-"+parentID+"-00")
+
+			m, _ := tracing.TraceParentReadHeader(header, "0")
+
+			Expect(m.Sampling).To(Equal(tracing.SamplingDrop))
+
+			tracing.TraceParentWriteHeader(header, tracing.NextMetadata(m, spanID))
+
+			Expect(header.Get(tracing.HeaderTraceParent)).To(Equal("00-" + traceID + "-" + spanID + "-00"))
+		})
+
+		It("should reject invalid traceparent", func() {
+			for _, v := range []string{
+				"",
+				"00-" + traceID + "-" + parentID,
+				"00-" + traceID + "-0000000000000000-01",
+				"00-" + traceID + "-" + parentID + "-01-extra",
+				"ff-" + traceID + "-" + parentID + "-01",
+			} {
+				header := http.Header{}
+				header.Set(tracing.HeaderTraceParent, v)
+
+				m, ok := tracing.TraceParentReadHeader(header, "0")
+
+				Expect(ok).To(BeFalse(), v)
+				Expect(m).To(Equal(tracing.NewMetadata("0")))
+			}
+		})
+
+		It("should write traceparent", func() {
+			header := http.Header{}
+			tracing.TraceParentWriteHeader(header, tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: traceID})
+
+			Expect(header.Get(tracing.HeaderTraceParent)).To(Equal("00-" + traceID + "-" + spanID + "-01"))
+
+			tracing.TraceParentWriteHeader(header, tracing.NewMetadata("1"))
+
+			Expect(header.Get(tracing.HeaderTraceParent)).To(MatchRegexp(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`))
+		})
+	})
+
+	Context("B3", func() {
+		It("should read multiple headers", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderB3TraceID, traceID)
+			header.Set(tracing.HeaderB3SpanID, spanID)
+			header.Set(tracing.HeaderB3ParentSpanID, parentID)
+
+			m, ok := tracing.B3ReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: traceID}))
+		})
+
+		It("should keep sampling state", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderB3TraceID, traceID)
+			header.Set(tracing.HeaderB3SpanID, spanID)
+			header.Set(tracing.HeaderB3Sampled, "0")
+
+			m, _ := tracing.B3ReadHeader(header, "0")
+
+			Expect(m.Sampling).To(Equal(tracing.SamplingDrop))
+
+			header.Set(tracing.HeaderB3Flags, "1")
+			m, _ = tracing.B3ReadHeader(header, "0")
+
+			Expect(m.Sampling).To(Equal(tracing.SamplingUserKeep))
+
+			header = http.Header{}
+			header.Set(tracing.HeaderB3, traceID+"-"+spanID+"-d")
+			m, _ = tracing.B3ReadHeader(header, "0")
+
+			Expect(m.Sampling).To(Equal(tracing.SamplingUserKeep))
+		})
+
+		It("should read single header", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderB3, traceID+"-"+spanID+"-1")
+
+			m, ok := tracing.B3ReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{
+				ID:            spanID,
+				CausationID:   spanID,
+				CorrelationID: traceID,
+				Sampling:      tracing.SamplingKeep,
+			}))
+		})
+
+		It("should write headers", func() {
+			m := tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: traceID, Sampling: tracing.SamplingKeep}
+			header := http.Header{}
+
+			tracing.B3WriteHeader(header, m)
+			tracing.B3SingleWriteHeader(header, m)
+
+			Expect(header).To(Equal(http.Header{
+				tracing.HeaderB3TraceID:      []string{traceID},
+				tracing.HeaderB3SpanID:       []string{spanID},
+				tracing.HeaderB3ParentSpanID: []string{parentID},
+				tracing.HeaderB3Sampled:      []string{"1"},
+				tracing.HeaderB3:             []string{traceID + "-" + spanID + "-1-" + parentID},
+			}))
+		})
+
+		It("should omit parent of root event", func() {
+			m := tracing.Metadata{ID: spanID, CausationID: spanID, CorrelationID: traceID, Sampling: tracing.SamplingUserKeep}
+			header := http.Header{}
+
+			tracing.B3WriteHeader(header, m)
+			tracing.B3SingleWriteHeader(header, m)
+
+			Expect(header).To(Equal(http.Header{
+				tracing.HeaderB3TraceID: []string{traceID},
+				tracing.HeaderB3SpanID:  []string{spanID},
+				tracing.HeaderB3Flags:   []string{"1"},
+				tracing.HeaderB3:        []string{traceID + "-" + spanID + "-d"},
+			}))
+		})
+
+		It("should omit unknown sampling decision", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderB3, traceID+"-"+parentID)
+
+			m, _ := tracing.B3ReadHeader(header, "0")
+			m = tracing.NextMetadata(m, spanID)
+			header = http.Header{}
+
+			tracing.B3WriteHeader(header, m)
+			tracing.B3SingleWriteHeader(header, m)
+
+			Expect(header).To(Equal(http.Header{
+				tracing.HeaderB3TraceID:      []string{traceID},
+				tracing.HeaderB3SpanID:       []string{spanID},
+				tracing.HeaderB3ParentSpanID: []string{parentID},
+				tracing.HeaderB3:             []string{traceID + "-" + spanID},
+			}))
+		})
+	})
+
+	Context("composite", func() {
+		opts := tracing.CompositeOptions(tracing.DefaultMetadataOptions, tracing.TraceParentOptions)
+		middleware := tracing.Middleware(opts, func() string { return spanID })
+
+		It("should read any format and write all formats", func() {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(tracing.HeaderTraceParent, "00-"+traceID+"-"+parentID+"-01")
+
+			result := tracingtest.Serve[tracing.Metadata](middleware, nil, req)
+			expected := tracing.Metadata{
+				ID:            spanID,
+				CausationID:   parentID,
+				CorrelationID: traceID,
+				Hops:          1,
+				Sampling:      tracing.SamplingKeep,
+			}
+
+			Expect(result.Tracing).To(Equal(expected))
+			Expect(result.Response.Header).To(HaveKeyWithValue(tracing.HeaderRequestID, []string{spanID}))
+			Expect(result.Response.Header).To(HaveKeyWithValue(tracing.HeaderCausationID, []string{parentID}))
+			Expect(result.Response.Header).To(HaveKeyWithValue(tracing.HeaderCorrelationID, []string{traceID}))
+			Expect(result.Response.Header).To(HaveKeyWithValue(
+				tracing.HeaderTraceParent,
+				[]string{"00-" + traceID + "-" + spanID + "-01"},
+			))
+		})
+
+		It("should prefer the first format", func() {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(tracing.HeaderTraceParent, "00-"+traceID+"-"+parentID+"-01")
+			tracing.DefaultMetadataWriteHeader(req.Header, tracing.Metadata{ID: "2", CausationID: "1", CorrelationID: "0"})
+
+			result := tracingtest.Serve[tracing.Metadata](middleware, nil, req)
+
+			Expect(result.Tracing).To(Equal(tracing.Metadata{ID: spanID, CausationID: "2", CorrelationID: "0", Hops: 1}))
+		})
+
+		It("should start new chain if no format is found", func() {
+			result := tracingtest.Serve[tracing.Metadata](middleware, nil, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			Expect(result.Tracing).To(Equal(tracing.NewMetadata(spanID)))
+		})
+	})
+
+	Context("AWS X-Ray", func() {
+		const root = "1-5759e988-bd862e3fe1be46a994272793"
+
+		It("should read root and parent", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderXRayTraceID, "Root="+root+";Parent="+parentID+";Sampled=1")
+
+			m, ok := tracing.XRayReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{
+				ID:            parentID,
+				CausationID:   parentID,
+				CorrelationID: root,
+				Sampling:      tracing.SamplingKeep,
+			}))
+		})
+
+		It("should keep sampling decision", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderXRayTraceID, "Root="+root+";Parent="+parentID+";Sampled=0")
+
+			m, _ := tracing.XRayReadHeader(header, "0")
+			tracing.XRayWriteHeader(header, tracing.NextMetadata(m, spanID))
+
+			Expect(m.Sampling).To(Equal(tracing.SamplingDrop))
+			Expect(header.Get(tracing.HeaderXRayTraceID)).To(Equal("Root=" + root + ";Parent=" + spanID + ";Sampled=0"))
+		})
+
+		It("should map root to 128-bit trace ID in other formats", func() {
+			header := http.Header{}
+			m := tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: root}
+
+			tracing.TraceParentWriteHeader(header, m)
+			tracing.B3WriteHeader(header, m)
+
+			Expect(header.Get(tracing.HeaderTraceParent)).To(Equal(
+				"00-5759e988bd862e3fe1be46a994272793-" + spanID + "-01",
+			))
+			Expect(header.Get(tracing.HeaderB3TraceID)).To(Equal("5759e988bd862e3fe1be46a994272793"))
+
+			read, _ := tracing.TraceParentReadHeader(header, "0")
+			tracing.XRayWriteHeader(header, read)
+
+			Expect(header.Get(tracing.HeaderXRayTraceID)).To(HavePrefix("Root=" + root + ";"))
+		})
+
+		It("should read root without parent", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderXRayTraceID, "Self=1-67891234-12456789abcdef012345678;Root="+root)
+
+			m, ok := tracing.XRayReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.NewMetadata(root)))
+		})
+
+		It("should reject invalid root", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderXRayTraceID, "Root=1-5759e988;Parent="+parentID)
+
+			_, ok := tracing.XRayReadHeader(header, "0")
+
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should write header", func() {
+			header := http.Header{}
+			tracing.XRayWriteHeader(header, tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: root})
+
+			Expect(header.Get(tracing.HeaderXRayTraceID)).To(Equal("Root=" + root + ";Parent=" + spanID + ";Sampled=1"))
+
+			tracing.XRayWriteHeader(header, tracing.NewMetadata("1"))
+
+			Expect(header.Get(tracing.HeaderXRayTraceID)).To(
+				MatchRegexp(`^Root=1-[0-9a-f]{8}-[0-9a-f]{24};Parent=[0-9a-f]{16};Sampled=1$`),
+			)
+		})
+
+		It("should generate compliant trace IDs for new chains", func() {
+			middleware := tracing.Middleware(tracing.XRayOptions, tracing.NewXRayTraceID)
+			result := tracingtest.Serve[tracing.Metadata](middleware, nil, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			Expect(result.Tracing.CorrelationID).To(MatchRegexp(`^1-[0-9a-f]{8}-[0-9a-f]{24}$`))
+			Expect(result.Response.Header.Get(tracing.HeaderXRayTraceID)).To(
+				HavePrefix("Root=" + result.Tracing.CorrelationID + ";"),
+			)
+		})
+	})
+
+	Context("Google Cloud", func() {
+		It("should read header", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderCloudTraceContext, traceID+"/1;o=1")
+
+			m, ok := tracing.CloudTraceReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{
+				ID:            "0000000000000001",
+				CausationID:   "0000000000000001",
+				CorrelationID: traceID,
+				Sampling:      tracing.SamplingKeep,
+			}))
+		})
+
+		It("should reject invalid header", func() {
+			for _, v := range []string{"", traceID, traceID + "/abc", traceID + "/0", "abc/1"} {
+				header := http.Header{}
+				header.Set(tracing.HeaderCloudTraceContext, v)
+
+				_, ok := tracing.CloudTraceReadHeader(header, "0")
+
+				Expect(ok).To(BeFalse(), v)
+			}
+		})
+
+		It("should write header with decimal span ID", func() {
+			header := http.Header{}
+			tracing.CloudTraceWriteHeader(header, tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: traceID})
+
+			Expect(header.Get(tracing.HeaderCloudTraceContext)).To(Equal(traceID + "/13235353014750950193;o=1"))
+		})
+
+		It("should round trip header", func() {
+			m := tracing.Metadata{ID: spanID, CausationID: spanID, CorrelationID: traceID, Sampling: tracing.SamplingDrop}
+			header := http.Header{}
+
+			tracing.CloudTraceWriteHeader(header, m)
+
+			Expect(header.Get(tracing.HeaderCloudTraceContext)).To(HaveSuffix(";o=0"))
+
+			read, ok := tracing.CloudTraceReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(read).To(Equal(m))
+		})
+
+		It("should build Cloud Logging trace fields", func() {
+			trace, span := tracing.CloudLoggingTrace(
+				"my-project",
+				tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: traceID},
+			)
+
+			Expect(trace).To(Equal("projects/my-project/traces/" + traceID))
+			Expect(span).To(Equal(spanID))
+		})
+	})
+
+	Context("Jaeger", func() {
+		It("should read header", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderUberTraceID, traceID+":"+spanID+":"+parentID+":1")
+
+			m, ok := tracing.JaegerReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{
+				ID:            spanID,
+				CausationID:   parentID,
+				CorrelationID: traceID,
+				Sampling:      tracing.SamplingKeep,
+			}))
+		})
+
+		It("should read URL encoded header without leading zeros and parent", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderUberTraceID, "abc%3A1%3A0%3A1")
+
+			m, ok := tracing.JaegerReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{
+				ID:            "0000000000000001",
+				CausationID:   "0000000000000001",
+				CorrelationID: "0000000000000abc",
+				Sampling:      tracing.SamplingKeep,
+			}))
+		})
+
+		It("should write header", func() {
+			header := http.Header{}
+			tracing.JaegerWriteHeader(header, tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: traceID})
+
+			Expect(header.Get(tracing.HeaderUberTraceID)).To(Equal(traceID + ":" + spanID + ":" + parentID + ":1"))
+		})
+
+		It("should write root event with zero parent and kept flags", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderUberTraceID, traceID+":"+spanID+":0:0")
+
+			m, _ := tracing.JaegerReadHeader(header, "0")
+			tracing.JaegerWriteHeader(header, m)
+
+			Expect(m.Sampling).To(Equal(tracing.SamplingDrop))
+			Expect(header.Get(tracing.HeaderUberTraceID)).To(Equal(traceID + ":" + spanID + ":0:0"))
+		})
+
+		It("should read and write baggage", func() {
+			header := http.Header{}
+			tracing.JaegerBaggageWriteHeader(header, tracing.Baggage{"user": "a b"})
+
+			Expect(header.Get("uberctx-user")).To(Equal("a%20b"))
+			Expect(tracing.JaegerBaggageReadHeader(header)).To(Equal(tracing.Baggage{"user": "a b"}))
+		})
+	})
+
+	Context("Datadog", func() {
+		It("should read headers", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderDatadogTraceID, "1")
+			header.Set(tracing.HeaderDatadogParentID, "13235353014750950193")
+
+			m, ok := tracing.DatadogReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{ID: spanID, CausationID: spanID, CorrelationID: "0000000000000001"}))
+		})
+
+		It("should reject invalid headers", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderDatadogTraceID, "abc")
+			header.Set(tracing.HeaderDatadogParentID, "1")
+
+			_, ok := tracing.DatadogReadHeader(header, "0")
+
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should write headers", func() {
+			header := http.Header{}
+
+			tracing.DatadogWriteHeader(header, tracing.Metadata{ID: spanID, CausationID: parentID, CorrelationID: traceID})
+
+			Expect(header).To(Equal(http.Header{
+				tracing.HeaderDatadogTraceID:  []string{"11803532876627986230"},
+				tracing.HeaderDatadogParentID: []string{"13235353014750950193"},
+			}))
+		})
+
+		It("should keep sampling priority and origin", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderDatadogTraceID, "1")
+			header.Set(tracing.HeaderDatadogParentID, "1")
+			header.Set(tracing.HeaderDatadogSamplingPriority, "-1")
+			header.Set(tracing.HeaderDatadogOrigin, "synthetics")
+
+			m, _ := tracing.DatadogReadHeader(header, "0")
+			b := tracing.DatadogBaggageReadHeader(header)
+
+			Expect(m.Sampling).To(Equal(tracing.SamplingUserDrop))
+			Expect(b).To(Equal(tracing.Baggage{tracing.DatadogBaggageOrigin: "synthetics"}))
+
+			header = http.Header{}
+			tracing.DatadogWriteHeader(header, tracing.NextMetadata(m, spanID))
+			tracing.DatadogBaggageWriteHeader(header, b)
+
+			Expect(header.Get(tracing.HeaderDatadogSamplingPriority)).To(Equal("-1"))
+			Expect(header.Get(tracing.HeaderDatadogOrigin)).To(Equal("synthetics"))
+		})
+
+		It("should zero-pad 64-bit trace ID in other formats", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderDatadogTraceID, "1234567890")
+			header.Set(tracing.HeaderDatadogParentID, "13235353014750950193")
+
+			m, _ := tracing.DatadogReadHeader(header, "0")
+			tracing.TraceParentWriteHeader(header, m)
+
+			Expect(header.Get(tracing.HeaderTraceParent)).To(Equal(
+				"00-000000000000000000000000499602d2-" + spanID + "-01",
+			))
+		})
+
+		It("should round trip headers", func() {
+			m := tracing.Metadata{ID: spanID, CausationID: spanID, CorrelationID: parentID, Sampling: tracing.SamplingUserKeep}
+			header := http.Header{}
+
+			tracing.DatadogWriteHeader(header, m)
+
+			read, ok := tracing.DatadogReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(read).To(Equal(m))
+		})
+	})
+
+	Context("Sentry", func() {
+		It("should read header", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderSentryTrace, traceID+"-"+spanID+"-1")
+
+			m, ok := tracing.SentryReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(tracing.Metadata{
+				ID:            spanID,
+				CausationID:   spanID,
+				CorrelationID: traceID,
+				Sampling:      tracing.SamplingKeep,
+			}))
+		})
+
+		It("should read header without sampling decision", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderSentryTrace, traceID+"-"+spanID)
+
+			m, ok := tracing.SentryReadHeader(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(m.Sampling).To(Equal(tracing.SamplingUnknown))
+		})
+
+		It("should write header keeping sampling decision", func() {
+			header := http.Header{}
+			header.Set(tracing.HeaderSentryTrace, traceID+"-"+parentID+"-0")
+
+			m, _ := tracing.SentryReadHeader(header, "0")
+			tracing.SentryWriteHeader(header, tracing.NextMetadata(m, spanID))
+
+			Expect(header.Get(tracing.HeaderSentryTrace)).To(Equal(traceID + "-" + spanID + "-0"))
+			Expect(header).NotTo(HaveKey(tracing.HeaderBaggage))
+		})
+
+		It("should forward dynamic sampling context keeping other entries", func() {
+			inbound := http.Header{}
+			inbound.Set(
+				tracing.HeaderBaggage,
+				"other=1,sentry-trace_id="+traceID+",sentry-public_key=abc,sentry-sample_rate=0.5",
+			)
+
+			header := http.Header{}
+			header.Set(tracing.HeaderBaggage, "mine=2,sentry-trace_id=old")
+			tracing.SentryBaggageWriteHeader(header, tracing.SentryBaggageReadHeader(inbound))
+
+			Expect(header.Get(tracing.HeaderBaggage)).To(Equal(
+				"mine=2,sentry-public_key=abc,sentry-sample_rate=0.5,sentry-trace_id=" + traceID,
+			))
+		})
+
+		It("should forward dynamic sampling context through context", func() {
+			var forwarded http.Header
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				forwarded = r.Header
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: tracing.Transport(
+				nil,
+				tracing.SentryOptions,
+				tracing.WithTransportBaggage(tracing.SentryBaggageWriteHeader),
+			)}
+			middleware := tracing.Middleware(
+				tracing.SentryOptions,
+				func() string { return spanID },
+				tracing.WithBaggageHeader(tracing.SentryBaggageReadHeader),
+			)
+			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, server.URL, nil)
+				resp, err := client.Do(req)
+
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp.Body.Close()
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(tracing.HeaderSentryTrace, traceID+"-"+parentID+"-1")
+			req.Header.Set(tracing.HeaderBaggage, "sentry-trace_id="+traceID+",sentry-release=1.0,sentry-environment=prod")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			Expect(forwarded.Get(tracing.HeaderSentryTrace)).To(Equal(traceID + "-" + spanID + "-1"))
+			Expect(forwarded.Get(tracing.HeaderBaggage)).To(Equal(
+				"sentry-environment=prod,sentry-release=1.0,sentry-trace_id=" + traceID,
+			))
+		})
+	})
+})
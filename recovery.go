@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicEntry carries information about recovered panic.
+type PanicEntry struct {
+	// Recovered value.
+	Value any
+	// Stack trace of panicking goroutine.
+	Stack []byte
+	// Request method.
+	Method string
+	// Request URI as sent by client.
+	URI string
+	// Tracing ID.
+	RequestID string
+	// ID of event that caused request.
+	CausationID string
+	// Root event ID of execution chain.
+	CorrelationID string
+}
+
+// Panic recovery middleware.
+// Recovers handler panics, calls log and responds with 500 and request ID in body.
+// If response was already written, panics with http.ErrAbortHandler after log
+// to abort the connection, so client does not take partial response as complete.
+// http.ErrAbortHandler is not recovered.
+// Should be used after Middleware to include tracing.
+func Recovery(log func(PanicEntry)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			rw := newResponseWriter(w)
+
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+
+				entry := PanicEntry{
+					Value:  v,
+					Stack:  debug.Stack(),
+					Method: req.Method,
+					URI:    req.RequestURI,
+				}
+
+				entry.RequestID, entry.CausationID, entry.CorrelationID = tracingIDs(req.Context())
+
+				log(entry)
+
+				if rw.wroteHeader {
+					panic(http.ErrAbortHandler)
+				}
+
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				w.Header().Set("X-Content-Type-Options", "nosniff")
+				w.WriteHeader(http.StatusInternalServerError)
+
+				if entry.RequestID == "" {
+					fmt.Fprintln(w, http.StatusText(http.StatusInternalServerError))
+					return
+				}
+
+				fmt.Fprintf(w, "%s\nRequest ID: %s\n", http.StatusText(http.StatusInternalServerError), entry.RequestID)
+			}()
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
+
+// Panic log writer to structured logger.
+func SlogPanicLog(logger *slog.Logger) func(PanicEntry) {
+	return func(e PanicEntry) {
+		attrs := []any{
+			slog.Any("panic", e.Value),
+			slog.String("stack", string(e.Stack)),
+			slog.String("method", e.Method),
+			slog.String("uri", e.URI),
+		}
+
+		attrs = append(attrs, tracingAttrs(e.RequestID, e.CausationID, e.CorrelationID)...)
+
+		logger.Error("panic recovered", attrs...)
+	}
+}
@@ -0,0 +1,89 @@
+package tracing_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("Recovery", func() {
+	middleware := tracing.Middleware(tracing.DefaultMetadataOptions, func() string { return "0" })
+	panicHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("test")
+	})
+
+	It("should respond with request ID and log tracing", func() {
+		var entry tracing.PanicEntry
+
+		handler := middleware(tracing.Recovery(func(e tracing.PanicEntry) { entry = e })(panicHandler))
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		Expect(recorder.Body.String()).To(ContainSubstring("Request ID: 0"))
+		Expect(recorder.Header()).To(HaveKeyWithValue(tracing.HeaderRequestID, []string{"0"}))
+
+		Expect(entry.Value).To(Equal("test"))
+		Expect(string(entry.Stack)).To(ContainSubstring("recovery_test.go"))
+		Expect(entry.RequestID).To(Equal("0"))
+		Expect(entry.CausationID).To(Equal("0"))
+		Expect(entry.CorrelationID).To(Equal("0"))
+	})
+
+	It("should abort written response after log", func() {
+		logged := false
+		handler := tracing.Recovery(func(tracing.PanicEntry) { logged = true })(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				panic("test")
+			}),
+		)
+		recorder := httptest.NewRecorder()
+
+		Expect(func() {
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		}).To(PanicWith(http.ErrAbortHandler))
+		Expect(logged).To(BeTrue())
+		Expect(recorder.Code).To(Equal(http.StatusAccepted))
+		Expect(recorder.Body.String()).To(BeEmpty())
+	})
+
+	It("should not let client read partial response as complete", func() {
+		server := httptest.NewServer(tracing.Recovery(func(tracing.PanicEntry) {})(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("partial"))
+				w.(http.Flusher).Flush()
+				panic("test")
+			}),
+		))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		defer resp.Body.Close()
+
+		_, err = io.ReadAll(resp.Body)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not recover http.ErrAbortHandler", func() {
+		handler := tracing.Recovery(func(tracing.PanicEntry) {})(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic(http.ErrAbortHandler)
+			}),
+		)
+
+		Expect(func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}).To(PanicWith(http.ErrAbortHandler))
+	})
+})
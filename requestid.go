@@ -32,8 +32,16 @@ func ValidRequestID(r RequestID) bool {
 // RequestID reader from Header using provided Header name.
 // Will canonicalize provided name.
 func RequestIDReadHeader(requestID string) func(header http.Header, id string) (RequestID, bool) {
+	return RequestIDReadHeaders(requestID)
+}
+
+// RequestID reader from Header using provided ordered list of Header names.
+// RequestID is read from the first present Header.
+// Header values are used as is, e.g. the whole X-Amzn-Trace-Id value.
+// Will canonicalize provided names.
+func RequestIDReadHeaders(requestIDs ...string) func(header http.Header, id string) (RequestID, bool) {
 	return func(header http.Header, id string) (RequestID, bool) {
-		r := RequestID(header.Get(requestID))
+		r := RequestID(firstHeader(header, requestIDs))
 
 		if ValidRequestID(r) {
 			return r, true
@@ -212,4 +212,26 @@ var _ = Describe("RequestID", func() {
 			resp.Body.Close()
 		})
 	})
+
+	Context("should read RequestID from the first present header alias", func() {
+		read := tracing.RequestIDReadHeaders("X-Request-Id", "Request-Id", "X-Amzn-Trace-Id")
+
+		It("in order of aliases", func() {
+			header := http.Header{}
+			header.Set("X-Amzn-Trace-Id", "Root=1-5759e988-bd862e3fe1be46a994272793")
+			header.Set("Request-Id", "1")
+
+			requestID, ok := read(header, "0")
+
+			Expect(ok).To(BeTrue())
+			Expect(requestID).To(Equal(tracing.RequestID("1")))
+		})
+
+		It("or return new ID if none is present", func() {
+			requestID, ok := read(http.Header{}, "0")
+
+			Expect(ok).To(BeFalse())
+			Expect(requestID).To(Equal(tracing.RequestID("0")))
+		})
+	})
 })
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"runtime/trace"
+)
+
+// Creates runtime/trace Task of taskType annotated with tracing from context.
+// Task should be ended by caller.
+func NewTask(ctx context.Context, taskType string) (context.Context, *trace.Task) {
+	ctx, task := trace.NewTask(ctx, taskType)
+
+	logTracing(ctx)
+
+	return ctx, task
+}
+
+// Starts runtime/trace Region of regionType.
+// Region should be ended by caller in the same goroutine.
+func StartRegion(ctx context.Context, regionType string) *trace.Region {
+	return trace.StartRegion(ctx, regionType)
+}
+
+// Calls fn inside runtime/trace Region of regionType.
+func WithRegion(ctx context.Context, regionType string, fn func()) {
+	trace.WithRegion(ctx, regionType, fn)
+}
+
+// Task middleware.
+// Serves request inside runtime/trace Task of "HTTP <method>" type
+// annotated with tracing from request context and request path.
+// Path is logged rather than used in task type to keep the set of task types small.
+// Should be used after Middleware.
+func TaskMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, task := NewTask(req.Context(), "HTTP "+req.Method)
+		defer task.End()
+
+		trace.Log(ctx, "path", req.URL.Path)
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+func logTracing(ctx context.Context) {
+	if !trace.IsEnabled() {
+		return
+	}
+
+	if m, ok := GetTracing[Metadata](ctx); ok {
+		trace.Log(ctx, LabelRequestID, m.ID)
+		trace.Log(ctx, LabelCausationID, m.CausationID)
+		trace.Log(ctx, LabelCorrelationID, m.CorrelationID)
+
+		return
+	}
+
+	if r, ok := GetTracing[RequestID](ctx); ok {
+		trace.Log(ctx, LabelRequestID, string(r))
+	}
+}
@@ -0,0 +1,45 @@
+package tracing_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"runtime/trace"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+var _ = Describe("Task", func() {
+	It("should serve request inside task with tracing", func() {
+		var buf bytes.Buffer
+
+		Expect(trace.Start(&buf)).ShouldNot(HaveOccurred())
+
+		middleware := tracing.Middleware(tracing.DefaultMetadataOptions, func() string { return "0" })
+		called := false
+		handler := middleware(tracing.TaskMiddleware(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+
+				tracing.WithRegion(r.Context(), "step", func() {
+					metadata, ok := tracing.GetTracing[tracing.Metadata](r.Context())
+
+					Expect(ok).To(BeTrue())
+					Expect(metadata.ID).To(Equal("0"))
+				})
+			}),
+		))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", nil))
+		trace.Stop()
+
+		Expect(called).To(BeTrue())
+		Expect(buf.String()).To(ContainSubstring("HTTP GET"))
+		Expect(buf.String()).To(ContainSubstring("/users/123"))
+		Expect(buf.String()).NotTo(ContainSubstring("GET /users/123"))
+		Expect(buf.String()).To(ContainSubstring(tracing.LabelCorrelationID))
+	})
+})
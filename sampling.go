@@ -0,0 +1,37 @@
+package tracing
+
+// Sampling decision propagated with Metadata.
+// Zero value means no decision was made, so downstream services decide.
+type Sampling int8
+
+const (
+	// No sampling decision was made.
+	SamplingUnknown Sampling = iota
+	// Trace was explicitly dropped by user.
+	SamplingUserDrop
+	// Trace was dropped by sampler.
+	SamplingDrop
+	// Trace was kept by sampler.
+	SamplingKeep
+	// Trace was explicitly kept by user or marked for debug.
+	SamplingUserKeep
+)
+
+// Reports whether trace is kept.
+// Unknown decision is reported as kept, as tracing clients do for new traces.
+func (s Sampling) Sampled() bool {
+	return s == SamplingUnknown || s >= SamplingKeep
+}
+
+// Converts boolean sampled flag to Sampling.
+func samplingFlag(sampled bool) Sampling {
+	if sampled {
+		return SamplingKeep
+	}
+
+	return SamplingDrop
+}
+
+func validSampling(s Sampling) bool {
+	return s >= SamplingUnknown && s <= SamplingUserKeep
+}
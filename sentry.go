@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// Sentry trace header name.
+	HeaderSentryTrace string = "Sentry-Trace"
+	// W3C baggage header name used by Sentry.
+	HeaderBaggage string = "Baggage"
+	// Sentry baggage entries key prefix.
+	SentryBaggagePrefix string = "sentry-"
+)
+
+var (
+	// Metadata reader from Sentry trace Header.
+	SentryReadHeader = readSentry
+	// Metadata writer to Sentry trace Header.
+	SentryWriteHeader = writeSentry
+	// Metadata options using Sentry Headers.
+	SentryOptions = MetadataOptions(SentryReadHeader, SentryWriteHeader)
+)
+
+// Reads sentry-* entries from baggage Header.
+// Keys keep sentry- prefix.
+// Use with WithBaggageHeader to read Sentry dynamic sampling context to request context.
+func SentryBaggageReadHeader(header http.Header) Baggage {
+	b := Baggage{}
+
+	for key, value := range readBaggage(header) {
+		if strings.HasPrefix(key, SentryBaggagePrefix) {
+			b[key] = value
+		}
+	}
+
+	return b
+}
+
+// Writes sentry-* entries of b to baggage Header keeping its other entries.
+// Properties of baggage members are not kept.
+// Use with WithTransportBaggage to forward Sentry dynamic sampling context from request context.
+func SentryBaggageWriteHeader(header http.Header, b Baggage) {
+	entries := readBaggage(header)
+
+	for key, value := range b {
+		if strings.HasPrefix(key, SentryBaggagePrefix) {
+			entries[key] = value
+		}
+	}
+
+	writeBaggage(header, entries)
+}
+
+// Maps trace_id to CorrelationID, span_id to ID and CausationID and sampled to Sampling.
+func readSentry(header http.Header, id string) (Metadata, bool) {
+	parts := strings.Split(strings.TrimSpace(header.Get(HeaderSentryTrace)), "-")
+	if len(parts) < 2 || len(parts) > 3 {
+		return NewMetadata(id), false
+	}
+
+	traceID, spanID := strings.ToLower(parts[0]), strings.ToLower(parts[1])
+	if !isHexID(traceID, 32) || !isHexID(spanID, 16) {
+		return NewMetadata(id), false
+	}
+
+	sampling := SamplingUnknown
+	if len(parts) == 3 && (parts[2] == "0" || parts[2] == "1") {
+		sampling = samplingFlag(parts[2] == "1")
+	}
+
+	return Metadata{ID: spanID, CausationID: spanID, CorrelationID: traceID, Sampling: sampling}, true
+}
+
+// Writes "<trace_id>-<span_id>-<sampled>".
+// Dynamic sampling context is not written, it is forwarded with SentryBaggageWriteHeader.
+func writeSentry(header http.Header, m Metadata) {
+	sampled := "0"
+	if m.Sampling.Sampled() {
+		sampled = "1"
+	}
+
+	header.Set(HeaderSentryTrace, hexID(m.CorrelationID, 32)+"-"+hexID(m.ID, 16)+"-"+sampled)
+}
+
+func readBaggage(header http.Header) Baggage {
+	b := Baggage{}
+
+	for _, line := range header.Values(HeaderBaggage) {
+		for _, member := range strings.Split(line, ",") {
+			member, _, _ = strings.Cut(member, ";")
+			key, value, ok := strings.Cut(member, "=")
+			if !ok {
+				continue
+			}
+
+			value, err := url.PathUnescape(strings.TrimSpace(value))
+			if err != nil {
+				continue
+			}
+
+			b[strings.TrimSpace(key)] = value
+		}
+	}
+
+	return b
+}
+
+func writeBaggage(header http.Header, b Baggage) {
+	keys := make([]string, 0, len(b))
+	for key := range b {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	members := make([]string, len(keys))
+	for i, key := range keys {
+		members[i] = key + "=" + url.PathEscape(b[key])
+	}
+
+	header.Set(HeaderBaggage, strings.Join(members, ","))
+}
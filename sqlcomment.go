@@ -0,0 +1,204 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Wraps driver.Driver.
+// Appends sqlcommenter comment with tracing from context to every query and exec.
+// Prepared statements are not annotated, so they are not bound to tracing
+// of the context they were prepared in.
+func WrapDriver(d driver.Driver) driver.Driver {
+	return &commentDriver{Driver: d}
+}
+
+// Wraps driver.Connector.
+// Appends sqlcommenter comment with tracing from context to every query and exec.
+// Can be used with sql.OpenDB.
+func WrapConnector(c driver.Connector) driver.Connector {
+	return &commentConnector{Connector: c, driver: WrapDriver(c.Driver())}
+}
+
+// Appends sqlcommenter comment with tracing from context to query.
+// Queries that already have comment are returned as is.
+func SQLComment(ctx context.Context, query string) string {
+	if strings.Contains(query, "/*") {
+		return query
+	}
+
+	tags := map[string]string{}
+
+	if m, ok := GetTracing[Metadata](ctx); ok && ValidMetadata(&m) {
+		tags["request_id"] = m.ID
+		tags["traceparent"] = traceParent(m)
+	} else if r, ok := GetTracing[RequestID](ctx); ok && ValidRequestID(r) {
+		tags["request_id"] = string(r)
+	}
+
+	if len(tags) == 0 {
+		return query
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = url.PathEscape(k) + "='" + url.PathEscape(tags[k]) + "'"
+	}
+
+	return query + " /*" + strings.Join(pairs, ",") + "*/"
+}
+
+type commentDriver struct {
+	driver.Driver
+}
+
+func (d *commentDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commentConn{Conn: conn}, nil
+}
+
+func (d *commentDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.Driver.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &commentConnector{Connector: c, driver: d}, nil
+	}
+
+	return &dsnConnector{name: name, driver: d}, nil
+}
+
+type commentConnector struct {
+	driver.Connector
+
+	driver driver.Driver
+}
+
+func (c *commentConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commentConn{Conn: conn}, nil
+}
+
+func (c *commentConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type dsnConnector struct {
+	name   string
+	driver *commentDriver
+}
+
+func (c *dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type commentConn struct {
+	driver.Conn
+}
+
+func (c *commentConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+
+	return c.Conn.Prepare(query)
+}
+
+func (c *commentConn) ExecContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	return e.ExecContext(ctx, SQLComment(ctx, query), args)
+}
+
+func (c *commentConn) QueryContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	return q.QueryContext(ctx, SQLComment(ctx, query), args)
+}
+
+func (c *commentConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+
+	if opts.Isolation != 0 {
+		return nil, errors.New("tracing: driver does not support non-default isolation level")
+	}
+
+	if opts.ReadOnly {
+		return nil, errors.New("tracing: driver does not support read-only transactions")
+	}
+
+	return c.Conn.Begin()
+}
+
+func (c *commentConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *commentConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c *commentConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+
+	return true
+}
+
+func (c *commentConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if ch, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return ch.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
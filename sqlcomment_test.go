@@ -0,0 +1,139 @@
+package tracing_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+type fakeSQLConnector struct {
+	queries *[]string
+}
+
+func (c fakeSQLConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeSQLConn(c), nil
+}
+
+func (c fakeSQLConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeSQLConn struct {
+	queries *[]string
+}
+
+func (c fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	*c.queries = append(*c.queries, query)
+	return fakeSQLStmt{}, nil
+}
+
+func (c fakeSQLConn) Close() error { return nil }
+
+func (c fakeSQLConn) Begin() (driver.Tx, error) { return fakeSQLTx{}, nil }
+
+func (c fakeSQLConn) ExecContext(
+	_ context.Context,
+	query string,
+	_ []driver.NamedValue,
+) (driver.Result, error) {
+	*c.queries = append(*c.queries, query)
+	return driver.RowsAffected(1), nil
+}
+
+func (c fakeSQLConn) QueryContext(
+	_ context.Context,
+	query string,
+	_ []driver.NamedValue,
+) (driver.Rows, error) {
+	*c.queries = append(*c.queries, query)
+	return fakeSQLRows{}, nil
+}
+
+type fakeSQLStmt struct{}
+
+func (fakeSQLStmt) Close() error  { return nil }
+func (fakeSQLStmt) NumInput() int { return -1 }
+
+func (fakeSQLStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	return fakeSQLRows{}, nil
+}
+
+type fakeSQLTx struct{}
+
+func (fakeSQLTx) Commit() error   { return nil }
+func (fakeSQLTx) Rollback() error { return nil }
+
+type fakeSQLRows struct{}
+
+func (fakeSQLRows) Columns() []string         { return nil }
+func (fakeSQLRows) Close() error              { return nil }
+func (fakeSQLRows) Next([]driver.Value) error { return io.EOF }
+
+var _ = Describe("SQLComment", func() {
+	It("should annotate query with metadata", func() {
+		ctx := tracing.WithTracing(
+			context.Background(),
+			tracing.Metadata{
+				ID:            "00f067aa0ba902b7",
+				CausationID:   "1",
+				CorrelationID: "4bf92f35-77b3-4da6-a3ce-929d0e0e4736",
+			},
+		)
+
+		Expect(tracing.SQLComment(ctx, "SELECT 1")).To(Equal(
+			"SELECT 1 /*request_id='00f067aa0ba902b7'," +
+				"traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'*/",
+		))
+	})
+
+	It("should escape request ID", func() {
+		ctx := tracing.WithTracing(context.Background(), tracing.RequestID("a'*/b"))
+
+		Expect(tracing.SQLComment(ctx, "SELECT 1")).To(Equal("SELECT 1 /*request_id='a%27%2A%2Fb'*/"))
+	})
+
+	It("should keep query without tracing or with comment", func() {
+		ctx := tracing.WithTracing(context.Background(), tracing.RequestID("1"))
+
+		Expect(tracing.SQLComment(context.Background(), "SELECT 1")).To(Equal("SELECT 1"))
+		Expect(tracing.SQLComment(ctx, "SELECT 1 /* my */")).To(Equal("SELECT 1 /* my */"))
+	})
+
+	It("should annotate queries and execs but not prepared statements", func() {
+		queries := []string{}
+		db := sql.OpenDB(tracing.WrapConnector(fakeSQLConnector{queries: &queries}))
+		defer db.Close()
+
+		ctx := tracing.WithTracing(context.Background(), tracing.RequestID("1"))
+
+		_, err := db.ExecContext(ctx, "UPDATE t SET a = ?", 1)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		rows, err := db.QueryContext(ctx, "SELECT a FROM t")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rows.Close()).ShouldNot(HaveOccurred())
+
+		stmt, err := db.PrepareContext(ctx, "SELECT a FROM t WHERE a = ?")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(stmt.Close()).ShouldNot(HaveOccurred())
+
+		Expect(queries).To(Equal([]string{
+			"UPDATE t SET a = ? /*request_id='1'*/",
+			"SELECT a FROM t /*request_id='1'*/",
+			"SELECT a FROM t WHERE a = ?",
+		}))
+	})
+})
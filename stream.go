@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MessageTracer derives next Metadata for every message of long-lived connection
+// (WebSocket, Server-Sent Events) with connection Metadata as parent.
+// MessageTracer is safe for concurrent use if getID is.
+type MessageTracer struct {
+	parent Metadata
+	getID  func() string
+}
+
+// Creates new MessageTracer with parent Metadata from context.
+// Context RequestID or new Metadata is used as parent if context has no Metadata.
+func NewMessageTracer(ctx context.Context, getID func() string) *MessageTracer {
+	parent, ok := GetTracing[Metadata](ctx)
+	if !ok {
+		if r, ok := GetTracing[RequestID](ctx); ok {
+			parent = NewMetadata(string(r))
+		} else {
+			parent = NewMetadata(getID())
+		}
+	}
+
+	return &MessageTracer{parent: parent, getID: getID}
+}
+
+// Connection Metadata.
+func (t *MessageTracer) Parent() Metadata {
+	return t.parent
+}
+
+// Metadata for next message.
+func (t *MessageTracer) Next() Metadata {
+	return NextMetadata(t.parent, t.getID())
+}
+
+// Wraps data into Envelope with Metadata for next message.
+func (t *MessageTracer) Wrap(data any) (Envelope, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{Metadata: t.Next(), Data: b}, nil
+}
+
+// Context for handling received Envelope.
+// Uses next Metadata of Envelope if it is valid
+// or Metadata for next message of connection otherwise.
+func (t *MessageTracer) Receive(ctx context.Context, e Envelope) context.Context {
+	if ValidMetadata(&e.Metadata) {
+		return WithTracing(ctx, NextMetadata(e.Metadata, t.getID()))
+	}
+
+	return WithTracing(ctx, t.Next())
+}
+
+// Envelope carries message data together with its Metadata.
+// Can be used as WebSocket JSON message.
+type Envelope struct {
+	// Message tracing.
+	Metadata Metadata `json:"metadata"`
+	// Message data.
+	Data json.RawMessage `json:"data"`
+}
+
+// SSEWriter writes Server-Sent Events with next Metadata for every event.
+// Event id field is set to event ID,
+// CausationID and CorrelationID are written as comment.
+type SSEWriter struct {
+	w      io.Writer
+	tracer *MessageTracer
+}
+
+// Creates new SSEWriter with parent Metadata from request context.
+// Sets Server-Sent Events response headers.
+func NewSSEWriter(w http.ResponseWriter, req *http.Request, getID func() string) *SSEWriter {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	return &SSEWriter{w: w, tracer: NewMessageTracer(req.Context(), getID)}
+}
+
+// Writes event with data and flushes it to client.
+// event type is omitted if empty.
+// Returns event Metadata.
+func (s *SSEWriter) Send(event string, data []byte) (Metadata, error) {
+	m := s.tracer.Next()
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, ": %s=%s %s=%s\n", LabelCausationID, m.CausationID, LabelCorrelationID, m.CorrelationID)
+	fmt.Fprintf(&b, "id: %s\n", m.ID)
+
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+
+	b.WriteString("\n")
+
+	if _, err := io.WriteString(s.w, b.String()); err != nil {
+		return m, err
+	}
+
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return m, nil
+}
@@ -0,0 +1,88 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+	"github.com/andriiyaremenko/tracing/tracingtest"
+)
+
+var _ = Describe("Stream", func() {
+	connection := tracing.Metadata{ID: "b", CausationID: "a", CorrelationID: "a"}
+
+	Context("MessageTracer", func() {
+		It("should derive next metadata per message", func() {
+			ctx := tracing.WithTracing(context.Background(), connection)
+			tracer := tracing.NewMessageTracer(ctx, tracingtest.SequentialID())
+
+			Expect(tracer.Parent()).To(Equal(connection))
+			Expect(tracer.Next()).To(Equal(tracing.Metadata{ID: "0", CausationID: "b", CorrelationID: "a", Hops: 1}))
+			Expect(tracer.Next()).To(Equal(tracing.Metadata{ID: "1", CausationID: "b", CorrelationID: "a", Hops: 1}))
+		})
+
+		It("should wrap and receive envelopes", func() {
+			ctx := tracing.WithTracing(context.Background(), connection)
+			tracer := tracing.NewMessageTracer(ctx, tracingtest.SequentialID())
+
+			e, err := tracer.Wrap(map[string]string{"text": "hello"})
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			b, err := json.Marshal(e)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(b).To(MatchJSON(`{
+				"metadata": {"id": "0", "causationId": "b", "correlationId": "a", "hops": 1},
+				"data": {"text": "hello"}
+			}`))
+
+			var received tracing.Envelope
+
+			Expect(json.Unmarshal(b, &received)).ShouldNot(HaveOccurred())
+
+			m, _ := tracing.GetTracing[tracing.Metadata](tracer.Receive(ctx, received))
+
+			Expect(m).To(Equal(tracing.Metadata{ID: "1", CausationID: "0", CorrelationID: "a", Hops: 2}))
+
+			m, _ = tracing.GetTracing[tracing.Metadata](tracer.Receive(ctx, tracing.Envelope{}))
+
+			Expect(m).To(Equal(tracing.Metadata{ID: "2", CausationID: "b", CorrelationID: "a", Hops: 1}))
+		})
+	})
+
+	Context("SSEWriter", func() {
+		It("should write events with metadata", func() {
+			middleware := tracing.Middleware(tracing.DefaultMetadataOptions, tracingtest.SequentialID())
+			recorder := httptest.NewRecorder()
+
+			middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				sse := tracing.NewSSEWriter(w, r, tracingtest.SequentialID())
+
+				m, err := sse.Send("greeting", []byte("hello\nworld"))
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(m.CausationID).To(Equal("0"))
+				Expect(m.CorrelationID).To(Equal("0"))
+
+				_, err = sse.Send("", []byte("bye"))
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+			Expect(recorder.Flushed).To(BeTrue())
+			Expect(recorder.Body.String()).To(Equal(
+				": causation_id=0 correlation_id=0\nid: 0\nevent: greeting\ndata: hello\ndata: world\n\n" +
+					": causation_id=0 correlation_id=0\nid: 1\ndata: bye\n\n",
+			))
+		})
+	})
+})
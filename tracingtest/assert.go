@@ -0,0 +1,41 @@
+package tracingtest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+// Reports error if header has no tracing headers written by opts for t.
+func AssertTracingHeaders[T tracing.Metadata | tracing.RequestID](
+	tb testing.TB,
+	opts tracing.Options[T],
+	header http.Header,
+	t T,
+) {
+	tb.Helper()
+
+	if diff := headersDiff(opts, t, header); len(diff) != 0 {
+		tb.Errorf("tracing headers mismatch:\n%s", strings.Join(diff, "\n"))
+	}
+}
+
+// Reports error if actual is not next Metadata of parent in execution chain.
+func AssertCausedBy(tb testing.TB, actual, parent tracing.Metadata) {
+	tb.Helper()
+
+	if !causedBy(actual, parent) {
+		tb.Errorf("expected %+v to be caused by %+v", actual, parent)
+	}
+}
+
+// Reports error if actual does not have the same CorrelationID as other.
+func AssertSharesCorrelation(tb testing.TB, actual, other tracing.Metadata) {
+	tb.Helper()
+
+	if !sharesCorrelation(actual, other) {
+		tb.Errorf("expected %+v to share correlation with %+v", actual, other)
+	}
+}
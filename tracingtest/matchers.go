@@ -0,0 +1,143 @@
+package tracingtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+// Succeeds if actual http.Header, *http.Response, *http.Request or *httptest.ResponseRecorder
+// has tracing headers written by opts for t.
+func HaveTracingHeaders[T tracing.Metadata | tracing.RequestID](
+	opts tracing.Options[T],
+	t T,
+) types.GomegaMatcher {
+	return &tracingHeadersMatcher[T]{opts: opts, expected: t}
+}
+
+// Succeeds if actual Metadata is next Metadata of parent in execution chain.
+func BeCausedBy(parent tracing.Metadata) types.GomegaMatcher {
+	return &metadataMatcher{
+		expected: parent,
+		match:    causedBy,
+		relation: "to be caused by",
+	}
+}
+
+// Succeeds if actual Metadata has the same CorrelationID as other.
+func ShareCorrelationWith(other tracing.Metadata) types.GomegaMatcher {
+	return &metadataMatcher{
+		expected: other,
+		match:    sharesCorrelation,
+		relation: "to share correlation with",
+	}
+}
+
+type tracingHeadersMatcher[T tracing.Metadata | tracing.RequestID] struct {
+	opts     tracing.Options[T]
+	expected T
+	diff     []string
+}
+
+func (m *tracingHeadersMatcher[T]) Match(actual any) (bool, error) {
+	header, err := toHeader(actual)
+	if err != nil {
+		return false, err
+	}
+
+	m.diff = headersDiff(m.opts, m.expected, header)
+
+	return len(m.diff) == 0, nil
+}
+
+func (m *tracingHeadersMatcher[T]) FailureMessage(actual any) string {
+	return format.Message(actual, fmt.Sprintf("to have tracing headers of %+v, but", m.expected), m.diff)
+}
+
+func (m *tracingHeadersMatcher[T]) NegatedFailureMessage(actual any) string {
+	return format.Message(actual, fmt.Sprintf("not to have tracing headers of %+v", m.expected))
+}
+
+type metadataMatcher struct {
+	expected tracing.Metadata
+	match    func(actual, expected tracing.Metadata) bool
+	relation string
+}
+
+func (m *metadataMatcher) Match(actual any) (bool, error) {
+	switch v := actual.(type) {
+	case tracing.Metadata:
+		return m.match(v, m.expected), nil
+	case *tracing.Metadata:
+		return m.match(*v, m.expected), nil
+	default:
+		return false, fmt.Errorf("expected tracing.Metadata, got:\n%s", format.Object(actual, 1))
+	}
+}
+
+func (m *metadataMatcher) FailureMessage(actual any) string {
+	return format.Message(actual, m.relation, m.expected)
+}
+
+func (m *metadataMatcher) NegatedFailureMessage(actual any) string {
+	return format.Message(actual, "not "+m.relation, m.expected)
+}
+
+func causedBy(actual, parent tracing.Metadata) bool {
+	return actual.CausationID == parent.ID && actual.CorrelationID == parent.CorrelationID
+}
+
+func sharesCorrelation(actual, other tracing.Metadata) bool {
+	return actual.CorrelationID == other.CorrelationID
+}
+
+func headersDiff[T tracing.Metadata | tracing.RequestID](
+	opts tracing.Options[T],
+	t T,
+	actual http.Header,
+) []string {
+	_, write, _ := opts()
+	expected := http.Header{}
+
+	write(expected, t)
+
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	diff := []string{}
+	for _, name := range names {
+		if want, got := expected.Get(name), actual.Get(name); want != got {
+			diff = append(diff, fmt.Sprintf("%s: want %q, got %q", name, want, got))
+		}
+	}
+
+	return diff
+}
+
+func toHeader(actual any) (http.Header, error) {
+	switch v := actual.(type) {
+	case http.Header:
+		return v, nil
+	case *http.Response:
+		return v.Header, nil
+	case *http.Request:
+		return v.Header, nil
+	case *httptest.ResponseRecorder:
+		return v.Header(), nil
+	default:
+		return nil, fmt.Errorf(
+			"expected http.Header, *http.Response, *http.Request or *httptest.ResponseRecorder, got:\n%s",
+			format.Object(actual, 1),
+		)
+	}
+}
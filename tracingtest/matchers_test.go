@@ -0,0 +1,69 @@
+package tracingtest_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+	"github.com/andriiyaremenko/tracing/tracingtest"
+)
+
+type recordingTB struct {
+	testing.TB
+
+	errors []string
+}
+
+func (tb *recordingTB) Helper() {}
+
+func (tb *recordingTB) Errorf(f string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(f, args...))
+}
+
+var _ = Describe("Matchers", func() {
+	parent := tracing.Metadata{ID: "b", CausationID: "a", CorrelationID: "a"}
+	child := tracing.NextMetadata(parent, "c")
+	stranger := tracing.NewMetadata("d")
+
+	It("should match tracing headers", func() {
+		header := http.Header{}
+		tracing.DefaultMetadataWriteHeader(header, child)
+
+		Expect(header).To(tracingtest.HaveTracingHeaders(tracing.DefaultMetadataOptions, child))
+		Expect(header).NotTo(tracingtest.HaveTracingHeaders(tracing.DefaultMetadataOptions, parent))
+		Expect(&http.Response{Header: header}).To(tracingtest.HaveTracingHeaders(
+			tracing.DefaultRequestIDOptions,
+			tracing.RequestID("c"),
+		))
+	})
+
+	It("should match causation", func() {
+		Expect(child).To(tracingtest.BeCausedBy(parent))
+		Expect(parent).NotTo(tracingtest.BeCausedBy(child))
+		Expect(child).To(tracingtest.ShareCorrelationWith(parent))
+		Expect(child).NotTo(tracingtest.ShareCorrelationWith(stranger))
+	})
+
+	It("should report errors with testing.TB helpers", func() {
+		tb := &recordingTB{}
+		header := http.Header{}
+		tracing.DefaultMetadataWriteHeader(header, child)
+
+		tracingtest.AssertTracingHeaders(tb, tracing.DefaultMetadataOptions, header, child)
+		tracingtest.AssertCausedBy(tb, child, parent)
+		tracingtest.AssertSharesCorrelation(tb, child, parent)
+
+		Expect(tb.errors).To(BeEmpty())
+
+		tracingtest.AssertTracingHeaders(tb, tracing.DefaultMetadataOptions, header, parent)
+		tracingtest.AssertCausedBy(tb, parent, child)
+		tracingtest.AssertSharesCorrelation(tb, child, stranger)
+
+		Expect(tb.errors).To(HaveLen(3))
+		Expect(tb.errors[0]).To(ContainSubstring(`X-Request-Id: want "b", got "c"`))
+	})
+})
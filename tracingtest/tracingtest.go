@@ -0,0 +1,141 @@
+// This package provides utilities for testing code that uses tracing.
+package tracingtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/andriiyaremenko/tracing"
+)
+
+// Deterministic concurrency-safe ID generator.
+// Returns "0", "1", "2" and so on.
+func SequentialID() func() string {
+	var (
+		mu sync.Mutex
+		i  int
+	)
+
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		defer func() { i++ }()
+
+		return strconv.Itoa(i)
+	}
+}
+
+// Creates new request with tracing written to its Header.
+func NewRequest[T tracing.Metadata | tracing.RequestID](
+	method, target string,
+	write tracing.WriteHeader[T],
+	t T,
+) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+
+	write(req.Header, t)
+
+	return req
+}
+
+// Result of request served by Serve.
+type Result[T tracing.Metadata | tracing.RequestID] struct {
+	// Response written by handler.
+	Response *http.Response
+	// Tracing observed in handler context.
+	Tracing T
+	// Reports whether tracing was found in handler context.
+	Found bool
+}
+
+// Serves req with handler wrapped by middleware.
+// Returns response and tracing observed in handler context.
+func Serve[T tracing.Metadata | tracing.RequestID](
+	middleware func(http.Handler) http.Handler,
+	handler http.Handler,
+	req *http.Request,
+) Result[T] {
+	var result Result[T]
+
+	recorder := httptest.NewRecorder()
+	middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result.Tracing, result.Found = tracing.GetTracing[T](r.Context())
+
+		if handler != nil {
+			handler.ServeHTTP(w, r)
+		}
+	})).ServeHTTP(recorder, req)
+
+	result.Response = recorder.Result()
+
+	return result
+}
+
+// Recorder records all tracing calculated by Middleware.
+type Recorder[T tracing.Metadata | tracing.RequestID] struct {
+	mu   sync.Mutex
+	seen []T
+}
+
+// Wraps opts to record every tracing calculated by Middleware:
+// next tracing if it was found in Header or new tracing otherwise.
+func (r *Recorder[T]) Options(opts tracing.Options[T]) tracing.Options[T] {
+	return func() (tracing.ReadHeader[T], tracing.WriteHeader[T], tracing.Next[T]) {
+		read, write, next := opts()
+
+		recordingRead := func(header http.Header, id string) (T, bool) {
+			t, ok := read(header, id)
+			if !ok {
+				r.record(t)
+			}
+
+			return t, ok
+		}
+		recordingNext := func(t T, id string) T {
+			t = next(t, id)
+			r.record(t)
+
+			return t
+		}
+
+		return recordingRead, write, recordingNext
+	}
+}
+
+// Returns all recorded tracing in order it was calculated.
+func (r *Recorder[T]) All() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]T(nil), r.seen...)
+}
+
+// Returns last recorded tracing.
+func (r *Recorder[T]) Last() (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.seen) == 0 {
+		var t T
+		return t, false
+	}
+
+	return r.seen[len(r.seen)-1], true
+}
+
+// Removes all recorded tracing.
+func (r *Recorder[T]) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seen = nil
+}
+
+func (r *Recorder[T]) record(t T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seen = append(r.seen, t)
+}
@@ -0,0 +1,13 @@
+package tracingtest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTracingTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TracingTest Suite")
+}
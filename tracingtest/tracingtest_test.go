@@ -0,0 +1,113 @@
+package tracingtest_test
+
+import (
+	"net/http"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+	"github.com/andriiyaremenko/tracing/tracingtest"
+)
+
+var _ = Describe("TracingTest", func() {
+	It("should generate sequential IDs concurrently", func() {
+		getID := tracingtest.SequentialID()
+
+		var (
+			wg  sync.WaitGroup
+			mu  sync.Mutex
+			ids []string
+		)
+
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				id := getID()
+
+				mu.Lock()
+				ids = append(ids, id)
+				mu.Unlock()
+			}()
+		}
+
+		wg.Wait()
+
+		Expect(ids).To(ConsistOf("0", "1", "2"))
+	})
+
+	It("should serve request with metadata and return observed tracing", func() {
+		middleware := tracing.Middleware(tracing.DefaultMetadataOptions, tracingtest.SequentialID())
+		result := tracingtest.Serve[tracing.Metadata](
+			middleware,
+			nil,
+			tracingtest.NewRequest(
+				http.MethodGet,
+				"/",
+				tracing.DefaultMetadataWriteHeader,
+				tracing.Metadata{ID: "b", CausationID: "a", CorrelationID: "a"},
+			),
+		)
+
+		Expect(result.Found).To(BeTrue())
+		Expect(result.Tracing).To(Equal(tracing.Metadata{ID: "0", CausationID: "b", CorrelationID: "a", Hops: 1}))
+		Expect(result.Response.Header).To(HaveKeyWithValue(tracing.HeaderRequestID, []string{"0"}))
+	})
+
+	It("should record tracing written by middleware", func() {
+		var recorder tracingtest.Recorder[tracing.RequestID]
+
+		middleware := tracing.Middleware(
+			recorder.Options(tracing.DefaultRequestIDOptions),
+			tracingtest.SequentialID(),
+		)
+
+		tracingtest.Serve[tracing.RequestID](middleware, nil, tracingtest.NewRequest(
+			http.MethodGet,
+			"/",
+			tracing.DefaultRequestIDWriteHeader,
+			"a",
+		))
+		tracingtest.Serve[tracing.RequestID](middleware, nil, tracingtest.NewRequest(
+			http.MethodGet,
+			"/",
+			tracing.DefaultRequestIDWriteHeader,
+			"",
+		))
+
+		last, ok := recorder.Last()
+
+		Expect(ok).To(BeTrue())
+		Expect(last).To(Equal(tracing.RequestID("1")))
+		Expect(recorder.All()).To(Equal([]tracing.RequestID{"a", "1"}))
+
+		recorder.Reset()
+
+		Expect(recorder.All()).To(BeEmpty())
+	})
+
+	It("should record tracing with echo disabled", func() {
+		var recorder tracingtest.Recorder[tracing.Metadata]
+
+		middleware := tracing.Middleware(
+			recorder.Options(tracing.DefaultMetadataOptions),
+			tracingtest.SequentialID(),
+			tracing.WithoutEcho(),
+		)
+
+		result := tracingtest.Serve[tracing.Metadata](middleware, nil, tracingtest.NewRequest(
+			http.MethodGet,
+			"/",
+			tracing.DefaultMetadataWriteHeader,
+			tracing.Metadata{ID: "b", CausationID: "a", CorrelationID: "a"},
+		))
+
+		Expect(result.Response.Header).NotTo(HaveKey(tracing.HeaderRequestID))
+		Expect(recorder.All()).To(Equal([]tracing.Metadata{
+			{ID: "0", CausationID: "b", CorrelationID: "a", Hops: 1},
+		}))
+	})
+})
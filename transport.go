@@ -0,0 +1,183 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Transport option.
+type TransportOption func(*transportConfig)
+
+type transportConfig struct {
+	deadlineHeader string
+	baggage        []func(http.Header, Baggage)
+
+	maxAttempts int
+	retry       func(*http.Response, error) bool
+	backoff     func(attempt int) time.Duration
+}
+
+// Writes remaining time until request context deadline to header.
+func WithTransportDeadline(header string) TransportOption {
+	return func(cfg *transportConfig) {
+		cfg.deadlineHeader = header
+	}
+}
+
+// Writes baggage from request context to Header with write.
+// Can be provided several times to write baggage in several formats.
+func WithTransportBaggage(write func(http.Header, Baggage)) TransportOption {
+	return func(cfg *transportConfig) {
+		cfg.baggage = append(cfg.baggage, write)
+	}
+}
+
+// Retries request up to maxAttempts attempts in total while retry reports true.
+// Retry attempt number is written to HeaderRetryAttempt.
+// Requests with body are retried only if http.Request.GetBody is set.
+// Attempts are made immediately unless WithTransportBackoff is provided.
+func WithTransportRetry(maxAttempts int, retry func(*http.Response, error) bool) TransportOption {
+	return func(cfg *transportConfig) {
+		cfg.maxAttempts = maxAttempts
+		cfg.retry = retry
+	}
+}
+
+// Waits backoff(attempt) before retry attempt or until request context is done.
+func WithTransportBackoff(backoff func(attempt int) time.Duration) TransportOption {
+	return func(cfg *transportConfig) {
+		cfg.backoff = backoff
+	}
+}
+
+// Backoff doubling base delay for every retry attempt up to max.
+// Can be used as WithTransportBackoff argument.
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt; i++ {
+			if d > max/2 {
+				return max
+			}
+
+			d *= 2
+		}
+
+		if d > max {
+			return max
+		}
+
+		return d
+	}
+}
+
+// Tracing transport.
+// Writes Tracing and, if configured, baggage from request context to outbound request Header.
+// Metadata hop count is written to HeaderHopCount whatever Options are used.
+// Uses http.DefaultTransport if base is nil.
+func Transport[T Metadata | RequestID, Opts Options[T]](
+	base http.RoundTripper,
+	opts Opts,
+	options ...TransportOption,
+) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	cfg := &transportConfig{}
+	for _, option := range options {
+		option(cfg)
+	}
+
+	return roundTripper(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		req = req.Clone(ctx)
+
+		if t, ok := GetTracing[T](ctx); ok {
+			_, write, _ := opts()
+
+			write(req.Header, t)
+
+			if m, ok := any(t).(Metadata); ok && m.Hops > 0 {
+				req.Header.Set(HeaderHopCount, strconv.Itoa(m.Hops))
+			}
+		}
+
+		if b, ok := GetBaggage(ctx); ok {
+			for _, write := range cfg.baggage {
+				write(req.Header, b)
+			}
+		}
+
+		for attempt := 0; ; attempt++ {
+			if attempt > 0 {
+				req = req.Clone(ctx)
+				req.Header.Set(HeaderRetryAttempt, strconv.Itoa(attempt))
+
+				if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+
+					req.Body = body
+				}
+			}
+
+			if deadline, ok := ctx.Deadline(); ok && cfg.deadlineHeader != "" {
+				req.Header.Set(cfg.deadlineHeader, formatTimeout(time.Until(deadline)))
+			}
+
+			resp, err := base.RoundTrip(req)
+			if !cfg.shouldRetry(req, attempt, resp, err) {
+				return resp, err
+			}
+
+			if resp != nil {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+
+			if err := cfg.wait(ctx, attempt+1); err != nil {
+				return nil, err
+			}
+		}
+	})
+}
+
+func (cfg *transportConfig) wait(ctx context.Context, attempt int) error {
+	if cfg.backoff == nil {
+		return nil
+	}
+
+	timer := time.NewTimer(cfg.backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (cfg *transportConfig) shouldRetry(req *http.Request, attempt int, resp *http.Response, err error) bool {
+	if cfg.retry == nil || attempt+1 >= cfg.maxAttempts || req.Context().Err() != nil {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	return cfg.retry(resp, err)
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (rt roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt(req)
+}
@@ -0,0 +1,306 @@
+package tracing_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/andriiyaremenko/tracing"
+	"github.com/andriiyaremenko/tracing/tracingtest"
+)
+
+var _ = Describe("Transport", func() {
+	var (
+		received    http.Header
+		baggage     tracing.Baggage
+		deadline    time.Time
+		hasDeadline bool
+		server      *httptest.Server
+	)
+
+	serve := func(options ...tracing.MiddlewareOption) {
+		middleware := tracing.Middleware(tracing.DefaultMetadataOptions, tracingtest.SequentialID(), options...)
+		server = httptest.NewServer(middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header
+			baggage, _ = tracing.GetBaggage(r.Context())
+			deadline, hasDeadline = r.Context().Deadline()
+		})))
+	}
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should write tracing from context to request", func() {
+		serve()
+
+		client := &http.Client{Transport: tracing.Transport(nil, tracing.DefaultMetadataOptions)}
+		ctx := tracing.WithTracing(context.Background(), tracing.Metadata{ID: "2", CausationID: "1", CorrelationID: "0"})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		resp, err := client.Do(req)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp.Body.Close()
+
+		Expect(received).To(HaveKeyWithValue(tracing.HeaderRequestID, []string{"2"}))
+		Expect(received).To(HaveKeyWithValue(tracing.HeaderCausationID, []string{"1"}))
+		Expect(received).To(HaveKeyWithValue(tracing.HeaderCorrelationID, []string{"0"}))
+		Expect(req.Header).To(BeEmpty())
+	})
+
+	It("should propagate hop count with any format", func() {
+		calls := 0
+		server = httptest.NewServer(tracing.Middleware(
+			tracing.TraceParentOptions,
+			tracingtest.SequentialID(),
+			tracing.WithMaxHops(2),
+		)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+		})))
+
+		client := &http.Client{Transport: tracing.Transport(nil, tracing.TraceParentOptions)}
+		send := func(hops int) int {
+			ctx := tracing.WithTracing(
+				context.Background(),
+				tracing.Metadata{ID: "2", CausationID: "1", CorrelationID: "0", Hops: hops},
+			)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+			resp, err := client.Do(req)
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			resp.Body.Close()
+
+			return resp.StatusCode
+		}
+
+		Expect(send(1)).To(Equal(http.StatusOK))
+		Expect(send(10)).To(Equal(http.StatusLoopDetected))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should propagate baggage", func() {
+		serve(tracing.WithBaggageHeader(tracing.JaegerBaggageReadHeader))
+
+		client := &http.Client{Transport: tracing.Transport(
+			nil,
+			tracing.DefaultMetadataOptions,
+			tracing.WithTransportBaggage(tracing.JaegerBaggageWriteHeader),
+		)}
+		ctx := tracing.WithBaggage(context.Background(), tracing.Baggage{"user": "a b"})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		resp, err := client.Do(req)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp.Body.Close()
+
+		Expect(received.Get("Uberctx-User")).To(Equal("a%20b"))
+		Expect(baggage).To(Equal(tracing.Baggage{"user": "a b"}))
+	})
+
+	It("should propagate deadline", func() {
+		serve(tracing.WithDeadline(tracing.HeaderRequestDeadline, time.Minute))
+
+		client := &http.Client{Transport: tracing.Transport(
+			nil,
+			tracing.DefaultRequestIDOptions,
+			tracing.WithTransportDeadline(tracing.HeaderRequestDeadline),
+		)}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		clientDeadline, _ := ctx.Deadline()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		resp, err := client.Do(req)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp.Body.Close()
+
+		Expect(received.Get(tracing.HeaderRequestDeadline)).To(MatchRegexp(`^\d{1,8}[num]$`))
+		Expect(hasDeadline).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", clientDeadline, time.Second))
+	})
+
+	It("should cap inbound deadline", func() {
+		serve(tracing.WithDeadline(tracing.HeaderRequestDeadline, time.Second))
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set(tracing.HeaderRequestDeadline, "1H")
+
+		start := time.Now()
+		resp, err := http.DefaultClient.Do(req)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp.Body.Close()
+
+		Expect(hasDeadline).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", start.Add(time.Second), 500*time.Millisecond))
+	})
+
+	It("should clamp overflowing deadline", func() {
+		serve(tracing.WithDeadline(tracing.HeaderRequestDeadline, 0))
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set(tracing.HeaderRequestDeadline, "99999999H")
+
+		start := time.Now()
+		resp, err := http.DefaultClient.Do(req)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp.Body.Close()
+
+		Expect(hasDeadline).To(BeTrue())
+		Expect(deadline).To(BeTemporally(">", start.Add(100*365*24*time.Hour)))
+	})
+
+	It("should ignore invalid deadline", func() {
+		serve(tracing.WithDeadline(tracing.HeaderRequestDeadline, 0))
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set(tracing.HeaderRequestDeadline, "1x")
+
+		resp, err := http.DefaultClient.Do(req)
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp.Body.Close()
+
+		Expect(hasDeadline).To(BeFalse())
+	})
+
+	It("should retry with attempt number", func() {
+		attempts := []int{}
+		bodies := []string{}
+		server = httptest.NewServer(tracing.Middleware(tracing.DefaultRequestIDOptions, tracingtest.SequentialID())(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt, _ := tracing.GetRetryAttempt(r.Context())
+				body, _ := io.ReadAll(r.Body)
+
+				attempts = append(attempts, attempt)
+				bodies = append(bodies, string(body))
+
+				if attempt < 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}),
+		))
+
+		client := &http.Client{Transport: tracing.Transport(
+			nil,
+			tracing.DefaultRequestIDOptions,
+			tracing.WithTransportRetry(3, func(resp *http.Response, err error) bool {
+				return err != nil || resp.StatusCode == http.StatusServiceUnavailable
+			}),
+		)}
+
+		resp, err := client.Post(server.URL, "text/plain", strings.NewReader("body"))
+
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(attempts).To(Equal([]int{0, 1, 2}))
+		Expect(bodies).To(Equal([]string{"body", "body", "body"}))
+	})
+
+	Context("retry", func() {
+		var calls int
+
+		BeforeEach(func() {
+			calls = 0
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+		})
+
+		retryUnavailable := func(resp *http.Response, err error) bool {
+			return err != nil || resp.StatusCode == http.StatusServiceUnavailable
+		}
+
+		It("should retry requests with empty body", func() {
+			client := &http.Client{Transport: tracing.Transport(
+				nil,
+				tracing.DefaultRequestIDOptions,
+				tracing.WithTransportRetry(2, retryUnavailable),
+			)}
+			req, _ := http.NewRequest(http.MethodPost, server.URL, http.NoBody)
+
+			resp, err := client.Do(req)
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			resp.Body.Close()
+
+			Expect(calls).To(Equal(2))
+		})
+
+		It("should wait backoff between attempts", func() {
+			waits := []int{}
+			client := &http.Client{Transport: tracing.Transport(
+				nil,
+				tracing.DefaultRequestIDOptions,
+				tracing.WithTransportRetry(3, retryUnavailable),
+				tracing.WithTransportBackoff(func(attempt int) time.Duration {
+					waits = append(waits, attempt)
+					return 10 * time.Millisecond
+				}),
+			)}
+
+			start := time.Now()
+			resp, err := client.Get(server.URL)
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			resp.Body.Close()
+
+			Expect(calls).To(Equal(3))
+			Expect(waits).To(Equal([]int{1, 2}))
+			Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+		})
+
+		It("should stop waiting when context is done", func() {
+			client := &http.Client{Transport: tracing.Transport(
+				nil,
+				tracing.DefaultRequestIDOptions,
+				tracing.WithTransportRetry(3, retryUnavailable),
+				tracing.WithTransportBackoff(tracing.ExponentialBackoff(time.Hour, time.Hour)),
+			)}
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+			_, err := client.Do(req)
+
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+			Expect(calls).To(Equal(1))
+		})
+
+		It("should double backoff up to max", func() {
+			backoff := tracing.ExponentialBackoff(100*time.Millisecond, time.Second)
+
+			Expect(backoff(1)).To(Equal(100 * time.Millisecond))
+			Expect(backoff(2)).To(Equal(200 * time.Millisecond))
+			Expect(backoff(4)).To(Equal(800 * time.Millisecond))
+			Expect(backoff(5)).To(Equal(time.Second))
+			Expect(backoff(100)).To(Equal(time.Second))
+		})
+	})
+})
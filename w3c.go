@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// W3C Trace Context header name.
+const HeaderTraceParent string = "Traceparent"
+
+var (
+	// Metadata reader from W3C traceparent Header.
+	TraceParentReadHeader = readTraceParent
+	// Metadata writer to W3C traceparent Header.
+	TraceParentWriteHeader = writeTraceParent
+	// Metadata options using W3C traceparent Header.
+	TraceParentOptions = MetadataOptions(TraceParentReadHeader, TraceParentWriteHeader)
+)
+
+// Maps trace-id to CorrelationID and parent-id to ID and CausationID.
+// Sampled trace flag is kept as Sampling.
+func readTraceParent(header http.Header, id string) (Metadata, bool) {
+	traceID, parentID, sampling, ok := parseTraceParent(header.Get(HeaderTraceParent))
+	if !ok {
+		return NewMetadata(id), false
+	}
+
+	return Metadata{ID: parentID, CausationID: parentID, CorrelationID: traceID, Sampling: sampling}, true
+}
+
+// Writes "00-<trace-id>-<parent-id>-<trace-flags>" with ID as parent-id.
+func writeTraceParent(header http.Header, m Metadata) {
+	header.Set(HeaderTraceParent, traceParent(m))
+}
+
+// W3C traceparent value for Metadata.
+// CorrelationID is used as trace-id and ID as parent-id.
+// Sampled trace flag is set unless Sampling drops the trace.
+func traceParent(m Metadata) string {
+	flags := "00"
+	if m.Sampling.Sampled() {
+		flags = "01"
+	}
+
+	return "00-" + hexID(m.CorrelationID, 32) + "-" + hexID(m.ID, 16) + "-" + flags
+}
+
+func parseTraceParent(v string) (traceID, parentID string, sampling Sampling, ok bool) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || !isHex(parts[0]) {
+		return "", "", SamplingUnknown, false
+	}
+
+	// Version 00 has exactly four fields, future versions may append more.
+	if parts[0] == "00" && len(parts) != 4 {
+		return "", "", SamplingUnknown, false
+	}
+
+	traceID, parentID = parts[1], parts[2]
+	if !isHexID(traceID, 32) || !isHexID(parentID, 16) || len(parts[3]) != 2 || !isHex(parts[3]) {
+		return "", "", SamplingUnknown, false
+	}
+
+	flags, _ := strconv.ParseUint(parts[3], 16, 8)
+
+	return traceID, parentID, samplingFlag(flags&1 == 1), true
+}
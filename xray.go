@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AWS X-Ray trace header name.
+const HeaderXRayTraceID string = "X-Amzn-Trace-Id"
+
+var (
+	// Metadata reader from AWS X-Ray trace Header.
+	XRayReadHeader = readXRay
+	// Metadata writer to AWS X-Ray trace Header.
+	XRayWriteHeader = writeXRay
+	// Metadata options using AWS X-Ray trace Header.
+	// Use with NewXRayTraceID to start X-Ray compliant execution chains.
+	XRayOptions = MetadataOptions(XRayReadHeader, XRayWriteHeader)
+)
+
+// Generates X-Ray compliant trace ID: 1-<epoch seconds>-<96 random bits>.
+// Can be used as getID argument.
+func NewXRayTraceID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("tracing: read random bytes: %s", err))
+	}
+
+	return fmt.Sprintf("1-%08x-%s", time.Now().Unix(), hex.EncodeToString(b))
+}
+
+// Maps Root to CorrelationID, Parent to ID and CausationID and Sampled to Sampling.
+// Root is used instead of Parent if the latter is absent,
+// as it is for requests coming from load balancer.
+func readXRay(header http.Header, id string) (Metadata, bool) {
+	var root, parent string
+	sampling := SamplingUnknown
+
+	for _, field := range strings.Split(header.Get(HeaderXRayTraceID), ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+
+		switch key {
+		case "Root":
+			root = value
+		case "Parent":
+			parent = value
+		case "Sampled":
+			if value == "0" || value == "1" {
+				sampling = samplingFlag(value == "1")
+			}
+		}
+	}
+
+	if !isXRayTraceID(root) {
+		return NewMetadata(id), false
+	}
+
+	if !isHexID(parent, 16) {
+		parent = root
+	}
+
+	return Metadata{ID: parent, CausationID: parent, CorrelationID: root, Sampling: sampling}, true
+}
+
+// Writes "Root=<trace ID>;Parent=<ID>;Sampled=<0 or 1>".
+// X-Ray trace ID CorrelationID is written as is.
+func writeXRay(header http.Header, m Metadata) {
+	sampled := "0"
+	if m.Sampling.Sampled() {
+		sampled = "1"
+	}
+
+	header.Set(
+		HeaderXRayTraceID,
+		"Root="+xrayTraceID(m.CorrelationID)+";Parent="+hexID(m.ID, 16)+";Sampled="+sampled,
+	)
+}
+
+func xrayTraceID(id string) string {
+	if isXRayTraceID(id) {
+		return id
+	}
+
+	h := hexID(id, 32)
+
+	return "1-" + h[:8] + "-" + h[8:]
+}
+
+func isXRayTraceID(id string) bool {
+	parts := strings.Split(id, "-")
+
+	return len(parts) == 3 &&
+		parts[0] == "1" &&
+		len(parts[1]) == 8 && isHex(parts[1]) &&
+		len(parts[2]) == 24 && isHex(parts[2])
+}